@@ -0,0 +1,351 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/moshe-exe/lazyvibe/internal/data"
+)
+
+// listOptions holds the flags shared by the list commands.
+type listOptions struct {
+	period  data.Period
+	project string
+	sort    string // Field name, without a direction
+	desc    bool
+	limit   int
+	format  string
+}
+
+// parseListFlags parses the flags of a list command. defaultSort is a
+// field name, optionally followed by :asc or :desc; textSorts lists the
+// fields sorted A to Z unless a direction is given. On error it prints
+// the problem and usage, and returns false.
+func parseListFlags(name, about, defaultSort string, textSorts []string, args []string) (listOptions, bool) {
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	rangeFlag := fs.String("range", "all", "Time range: today, week, month, year, all, Nd for the last N days, a date, or FROM..TO (YYYY-MM-DD, either side optional)")
+	project := fs.String("project", "", "Only include projects whose name or path contains this text")
+	sortFlag := fs.String("sort", defaultSort, "Field to sort by, optionally followed by :asc or :desc")
+	limit := fs.Int("limit", 0, "Maximum number of rows to print (0 for all)")
+	format := fs.String("format", "table", "Output format: "+strings.Join(outputFormats, ", "))
+	addClaudeDirFlag(fs)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: lazyvibe %s [flags]\n\n%s\n\n", name, about)
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	fail := func(err error) (listOptions, bool) {
+		fmt.Fprintf(os.Stderr, "Error: %v\n\n", err)
+		fs.Usage()
+		return listOptions{}, false
+	}
+	if fs.NArg() > 0 {
+		return fail(fmt.Errorf("unexpected argument %q", fs.Arg(0)))
+	}
+
+	opts := listOptions{project: *project, limit: *limit, format: strings.ToLower(*format)}
+	var err error
+	if opts.period, err = data.ParsePeriod(*rangeFlag); err != nil {
+		return fail(err)
+	}
+
+	field, dir, hasDir := strings.Cut(strings.ToLower(*sortFlag), ":")
+	opts.sort = field
+	opts.desc = true
+	for _, text := range textSorts {
+		if field == text {
+			opts.desc = false
+		}
+	}
+	if hasDir {
+		switch dir {
+		case "asc":
+			opts.desc = false
+		case "desc":
+			opts.desc = true
+		default:
+			return fail(fmt.Errorf("invalid sort direction %q (want asc or desc)", dir))
+		}
+	}
+
+	if !validFormat(opts.format) {
+		return fail(fmt.Errorf("unknown format %q (want %s)", opts.format, strings.Join(outputFormats, ", ")))
+	}
+	if opts.limit < 0 {
+		return fail(fmt.Errorf("invalid limit %d", opts.limit))
+	}
+	return opts, true
+}
+
+func validFormat(format string) bool {
+	for _, f := range outputFormats {
+		if format == f {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesProject returns whether a project name or path contains the
+// --project text, ignoring case.
+func (o listOptions) matchesProject(name, path string) bool {
+	if o.project == "" {
+		return true
+	}
+	q := strings.ToLower(o.project)
+	return strings.Contains(strings.ToLower(name), q) || strings.Contains(strings.ToLower(path), q)
+}
+
+// sessions returns the sessions in the range and project, in a new slice.
+func (o listOptions) sessions(dashData *data.DashboardData) []data.SessionEntry {
+	var sessions []data.SessionEntry
+	for _, s := range dashData.SessionsIn(o.period) {
+		if o.matchesProject(s.ProjectName, s.ProjectPath) {
+			sessions = append(sessions, s)
+		}
+	}
+	return sessions
+}
+
+// limitRows returns at most the first --limit rows.
+func limitRows[T any](rows []T, limit int) []T {
+	if limit > 0 && len(rows) > limit {
+		return rows[:limit]
+	}
+	return rows
+}
+
+// sortOrder returns the ascending order of the --sort field from less,
+// which maps each field name to its order.
+func sortOrder[T any](opts listOptions, less map[string]func(a, b *T) bool) (func(a, b *T) bool, error) {
+	cmp, ok := less[opts.sort]
+	if !ok {
+		names := make([]string, 0, len(less))
+		for name := range less {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("unknown sort %q (want %s)", opts.sort, strings.Join(names, ", "))
+	}
+	return cmp, nil
+}
+
+// sortRows sorts rows by an ascending order, reversed if desc, keeping
+// the order of equal rows.
+func sortRows[T any](rows []T, less func(a, b *T) bool, desc bool) {
+	sort.SliceStable(rows, func(i, j int) bool {
+		if desc {
+			return less(&rows[j], &rows[i])
+		}
+		return less(&rows[i], &rows[j])
+	})
+}
+
+// loadData reads the dashboard data once for a command.
+func loadData(manager *data.Manager) data.DashboardData {
+	dashData := manager.GetDashboardData(false)
+	manager.Close()
+	return dashData
+}
+
+// printRows writes rows to stdout and returns the exit code.
+func printRows[T any](format string, columns []column[T], rows []T) int {
+	if err := writeRows(os.Stdout, format, columns, rows); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// usageError prints a usage error and returns its exit code.
+func usageError(err error) int {
+	fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	return 2
+}
+
+// runSessions lists the sessions last active in the range.
+func runSessions(newManager func() *data.Manager, args []string) int {
+	opts, ok := parseListFlags("sessions", "List sessions last active in the range, most recent first.",
+		"time", []string{"project"}, args)
+	if !ok {
+		return 2
+	}
+	field, err := data.ParseSessionSort(opts.sort)
+	if err != nil {
+		return usageError(err)
+	}
+
+	manager := newManager()
+	manager.LoadCommits()
+	dashData := loadData(manager)
+	sessions := opts.sessions(&dashData)
+	data.SortSessions(sessions, field, opts.desc)
+	return printRows(opts.format, sessionColumns, limitRows(sessions, opts.limit))
+}
+
+var sessionColumns = []column[data.SessionEntry]{
+	{"id", func(s data.SessionEntry) any { return s.SessionID }},
+	{"project", func(s data.SessionEntry) any { return s.ProjectName }},
+	{"branch", func(s data.SessionEntry) any {
+		if s.GitBranch == nil {
+			return ""
+		}
+		return *s.GitBranch
+	}},
+	{"started", func(s data.SessionEntry) any { return s.Created }},
+	{"last_active", func(s data.SessionEntry) any { return s.Modified }},
+	{"duration", func(s data.SessionEntry) any {
+		if !s.TimesKnown() {
+			return nil
+		}
+		return s.Duration()
+	}},
+	{"messages", func(s data.SessionEntry) any { return s.MessageCount }},
+	{"tokens", func(s data.SessionEntry) any { return s.Tokens.Total() }},
+	{"cost", func(s data.SessionEntry) any { return s.Cost }},
+	{"commits", func(s data.SessionEntry) any {
+		if s.Git == nil {
+			return nil
+		}
+		return len(s.Git.Commits)
+	}},
+	{"summary", func(s data.SessionEntry) any { return s.Summary }},
+}
+
+// runProjects lists the projects with sessions in the range. Totals
+// count only those sessions.
+func runProjects(newManager func() *data.Manager, args []string) int {
+	opts, ok := parseListFlags("projects", "List projects with sessions in the range, totalling only those sessions.",
+		"activity", []string{"name"}, args)
+	if !ok {
+		return 2
+	}
+	field, err := data.ParseProjectSort(opts.sort)
+	if err != nil {
+		return usageError(err)
+	}
+
+	manager := newManager()
+	manager.LoadCommits()
+	dashData := loadData(manager)
+	projects := data.AggregateProjects(opts.sessions(&dashData))
+	data.SortProjects(projects, field, opts.desc)
+	return printRows(opts.format, projectColumns, limitRows(projects, opts.limit))
+}
+
+var projectColumns = []column[data.ProjectSummary]{
+	{"project", func(p data.ProjectSummary) any { return p.ProjectName }},
+	{"path", func(p data.ProjectSummary) any { return p.ProjectPath }},
+	{"sessions", func(p data.ProjectSummary) any { return p.SessionCount }},
+	{"messages", func(p data.ProjectSummary) any { return p.TotalMessages }},
+	{"tokens", func(p data.ProjectSummary) any { return p.Tokens.Total() }},
+	{"cost", func(p data.ProjectSummary) any { return p.TotalCost }},
+	{"commits", func(p data.ProjectSummary) any {
+		if !p.HasGit {
+			return nil
+		}
+		return p.Commits
+	}},
+	{"duration", func(p data.ProjectSummary) any { return p.TotalDuration }},
+	{"last_active", func(p data.ProjectSummary) any { return p.LastActivity }},
+}
+
+// runStats prints usage per model in the range, followed by a total row
+// covering every model.
+func runStats(newManager func() *data.Manager, args []string) int {
+	opts, ok := parseListFlags("stats", "Print token usage and cost per model in the range, then the total.",
+		"tokens", []string{"model"}, args)
+	if !ok {
+		return 2
+	}
+	less, err := sortOrder(opts, modelSorts)
+	if err != nil {
+		return usageError(err)
+	}
+
+	dashData := loadData(newManager())
+	scoped := data.DashboardData{Sessions: opts.sessions(&dashData)}
+	models := scoped.ModelBreakdown(data.TimeAll)
+	sortRows(models, less, opts.desc)
+
+	total := data.ModelUsage{Model: "total", Sessions: len(scoped.Sessions)}
+	for _, m := range models {
+		total.Messages += m.Messages
+		total.Tokens.Add(m.Tokens)
+		total.Cost += m.Cost
+	}
+	rows := append(limitRows(models, opts.limit), total)
+	return printRows(opts.format, modelColumns, rows)
+}
+
+var modelSorts = map[string]func(a, b *data.ModelUsage) bool{
+	"model":    func(a, b *data.ModelUsage) bool { return a.Model < b.Model },
+	"sessions": func(a, b *data.ModelUsage) bool { return a.Sessions < b.Sessions },
+	"messages": func(a, b *data.ModelUsage) bool { return a.Messages < b.Messages },
+	"tokens":   func(a, b *data.ModelUsage) bool { return a.Tokens.Total() < b.Tokens.Total() },
+	"cost":     func(a, b *data.ModelUsage) bool { return a.Cost < b.Cost },
+}
+
+var modelColumns = []column[data.ModelUsage]{
+	{"model", func(m data.ModelUsage) any { return m.Model }},
+	{"sessions", func(m data.ModelUsage) any { return m.Sessions }},
+	{"messages", func(m data.ModelUsage) any { return m.Messages }},
+	{"input", func(m data.ModelUsage) any { return m.Tokens.Input }},
+	{"output", func(m data.ModelUsage) any { return m.Tokens.Output }},
+	{"cache_read", func(m data.ModelUsage) any { return m.Tokens.CacheRead }},
+	{"cache_write", func(m data.ModelUsage) any { return m.Tokens.CacheCreation }},
+	{"tokens", func(m data.ModelUsage) any { return m.Tokens.Total() }},
+	{"cost", func(m data.ModelUsage) any { return m.Cost }},
+}
+
+// runActivity prints activity per day in the range. With --project, days
+// are counted from the matching projects' sessions by the day each started.
+func runActivity(newManager func() *data.Manager, args []string) int {
+	opts, ok := parseListFlags("activity", "Print activity per day in the range, oldest first.",
+		"date:asc", []string{"date"}, args)
+	if !ok {
+		return 2
+	}
+	less, err := sortOrder(opts, daySorts)
+	if err != nil {
+		return usageError(err)
+	}
+
+	dashData := loadData(newManager())
+	days := dashData.DailyActivityIn(opts.period)
+	if opts.project != "" {
+		var sessions []data.SessionEntry
+		for _, s := range dashData.Sessions {
+			if opts.matchesProject(s.ProjectName, s.ProjectPath) {
+				sessions = append(sessions, s)
+			}
+		}
+		scoped := data.DashboardData{DailyActivity: data.DailyFromSessions(sessions)}
+		days = scoped.DailyActivityIn(opts.period)
+	}
+	days = append([]data.DailyActivity(nil), days...)
+	sortRows(days, less, opts.desc)
+	return printRows(opts.format, dayColumns, limitRows(days, opts.limit))
+}
+
+var daySorts = map[string]func(a, b *data.DailyActivity) bool{
+	"date":       func(a, b *data.DailyActivity) bool { return a.Date < b.Date },
+	"sessions":   func(a, b *data.DailyActivity) bool { return a.SessionCount < b.SessionCount },
+	"messages":   func(a, b *data.DailyActivity) bool { return a.MessageCount < b.MessageCount },
+	"tool_calls": func(a, b *data.DailyActivity) bool { return a.ToolCallCount < b.ToolCallCount },
+	"tokens":     func(a, b *data.DailyActivity) bool { return a.TokenCount < b.TokenCount },
+	"cost":       func(a, b *data.DailyActivity) bool { return a.Cost < b.Cost },
+}
+
+var dayColumns = []column[data.DailyActivity]{
+	{"date", func(d data.DailyActivity) any { return d.Date }},
+	{"sessions", func(d data.DailyActivity) any { return d.SessionCount }},
+	{"messages", func(d data.DailyActivity) any { return d.MessageCount }},
+	{"tool_calls", func(d data.DailyActivity) any { return d.ToolCallCount }},
+	{"tokens", func(d data.DailyActivity) any { return d.TokenCount }},
+	{"cost", func(d data.DailyActivity) any { return d.Cost }},
+}
@@ -16,34 +16,123 @@ import (
 )
 
 func main() {
-	// Load configuration
-	cfg, _ := config.Load()
-	if cfg != nil && cfg.Theme != "" {
+	// Load configuration, reporting errors along with data problems
+	cfg, cfgErr := config.Load()
+	if cfg.Theme != "" {
 		ui.ApplyTheme(cfg.Theme)
 	}
+	weekStart, err := data.ParseWeekday(cfg.WeekStart)
+	if err != nil && cfgErr == nil {
+		cfgErr = fmt.Errorf("week_start: %w", err)
+	}
+	data.SetWeekStart(weekStart)
+	if _, err := data.ParseTimeRange(cfg.DefaultTimeRange); err != nil && cfgErr == nil {
+		cfgErr = fmt.Errorf("default_time_range: %w", err)
+	}
 
 	// CLI flags
 	dump := flag.Bool("dump", false, "Dump all dashboard data as JSON and exit")
 	capture := flag.String("capture", "", "Capture visual output as ASCII text at specified terminal size (e.g., 120x40) and exit")
+	addClaudeDirFlag(flag.CommandLine)
+	flag.Usage = usage
 	flag.Parse()
 
+	// Commands call this after parsing their own flags, which may add
+	// directories
+	newManager := func() *data.Manager {
+		if len(claudeDirs) > 0 {
+			cfg.ClaudeDirs = claudeDirs
+		}
+		manager := data.NewManager(cfg)
+		if cfgErr != nil {
+			manager.AddConfigError(config.Path(), cfgErr)
+		}
+		return manager
+	}
+
+	switch flag.Arg(0) {
+	case "":
+	case "doctor":
+		os.Exit(runDoctor(newManager, flag.Args()[1:]))
+	case "search":
+		os.Exit(runSearch(newManager, flag.Args()[1:]))
+	case "sessions":
+		os.Exit(runSessions(newManager, flag.Args()[1:]))
+	case "projects":
+		os.Exit(runProjects(newManager, flag.Args()[1:]))
+	case "stats":
+		os.Exit(runStats(newManager, flag.Args()[1:]))
+	case "activity":
+		os.Exit(runActivity(newManager, flag.Args()[1:]))
+	case "report":
+		os.Exit(runReport(newManager, flag.Args()[1:]))
+	default:
+		fmt.Fprintf(os.Stderr, "Error: unknown command %q\n", flag.Arg(0))
+		os.Exit(2)
+	}
+
 	if *dump {
-		dumpData()
+		dumpData(newManager())
 		return
 	}
 
 	if *capture != "" {
-		runCapture(*capture)
+		runCapture(newManager(), cfg, *capture)
 		return
 	}
 
 	// Normal TUI mode
-	runTUI()
+	runTUI(newManager(), cfg)
+}
+
+// usage prints the commands and global flags.
+func usage() {
+	out := flag.CommandLine.Output()
+	fmt.Fprintln(out, "Usage: lazyvibe [flags] [command]")
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "Without a command, lazyvibe runs the dashboard.")
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "Commands:")
+	fmt.Fprintln(out, "  sessions   List sessions")
+	fmt.Fprintln(out, "  projects   List projects")
+	fmt.Fprintln(out, "  stats      Print token usage and cost per model")
+	fmt.Fprintln(out, "  activity   Print activity per day")
+	fmt.Fprintln(out, "  report     Write a weekly or monthly report in Markdown or HTML")
+	fmt.Fprintln(out, "  search     Search prompts and responses")
+	fmt.Fprintln(out, "  doctor     Report data problems")
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "Run lazyvibe <command> -h for a command's flags.")
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "Flags:")
+	flag.PrintDefaults()
+}
+
+// claudeDirs collects --claude-dir, which is accepted before or after the
+// command.
+var claudeDirs stringList
+
+// addClaudeDirFlag registers --claude-dir with a set of flags.
+func addClaudeDirFlag(fs *flag.FlagSet) {
+	fs.Var(&claudeDirs, "claude-dir", "Claude data directory to read (repeatable, overrides config and CLAUDE_CONFIG_DIR)")
+}
+
+// stringList is a flag value that collects repeated flags.
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
 }
 
-func dumpData() {
-	manager := data.NewManager()
+func dumpData(manager *data.Manager) {
+	defer manager.Close()
+	manager.LoadCommits()
 	dashData := manager.GetDashboardData(false)
+	files := dashData.FileBreakdown(data.TimeAll)
 
 	// Convert to JSON-friendly structure
 	output := map[string]interface{}{
@@ -53,13 +142,20 @@ func dumpData() {
 			"cpu_percent": dashData.VMStatus.CPUPercent,
 			"memory_mb":   dashData.VMStatus.MemoryMB,
 		},
+		"processes":      dashData.Processes,
 		"sessions":       dashData.Sessions,
 		"daily_activity": dashData.DailyActivity,
 		"projects":       dashData.Projects,
-		"totals": map[string]int{
+		"diagnostics":    dashData.Diagnostics,
+		"tools":          dashData.ToolBreakdown(data.TimeAll),
+		"files":          files,
+		"directories":    data.DirectoryBreakdown(files),
+		"totals": map[string]interface{}{
 			"sessions":   dashData.TotalSessions(),
 			"messages":   dashData.TotalMessages(),
 			"tool_calls": dashData.TotalToolCalls(),
+			"tokens":     dashData.TotalTokens(),
+			"cost_usd":   dashData.TotalCost(),
 		},
 	}
 
@@ -71,17 +167,18 @@ func dumpData() {
 	}
 }
 
-func runCapture(sizeStr string) {
+func runCapture(manager *data.Manager, cfg *config.Config, sizeStr string) {
 	width, height, err := parseSize(sizeStr)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 
-	manager := data.NewManager()
-	model := ui.NewModel(manager)
+	defer manager.Close()
+	model := ui.NewModel(manager, cfg)
 
 	// Simulate window size and data load
+	manager.LoadCommits()
 	dashData := manager.GetDashboardData(false)
 
 	// Create a new model with the size
@@ -119,9 +216,9 @@ func parseSize(sizeStr string) (int, int, error) {
 	return width, height, nil
 }
 
-func runTUI() {
-	manager := data.NewManager()
-	model := ui.NewModel(manager)
+func runTUI(manager *data.Manager, cfg *config.Config) {
+	defer manager.Close()
+	model := ui.NewModel(manager, cfg)
 
 	p := tea.NewProgram(model,
 		tea.WithAltScreen(),
@@ -132,3 +229,87 @@ func runTUI() {
 		os.Exit(1)
 	}
 }
+
+// runDoctor prints a report of the data read and the problems found in it.
+// It returns the exit code: 1 if there are problems, 0 otherwise.
+func runDoctor(newManager func() *data.Manager, args []string) int {
+	fs := flag.NewFlagSet("doctor", flag.ExitOnError)
+	addClaudeDirFlag(fs)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: lazyvibe doctor [flags]\n\nReport the data read and the problems found in it.\n\n")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if fs.NArg() > 0 {
+		fmt.Fprintf(os.Stderr, "Error: unexpected argument %q\n\n", fs.Arg(0))
+		fs.Usage()
+		return 2
+	}
+
+	manager := newManager()
+	diag := manager.GetDashboardData(false).Diagnostics
+	manager.Close()
+
+	for _, root := range diag.Roots {
+		fmt.Printf("Directory:   %s\n", root)
+	}
+	fmt.Printf("Indexes:     %d\n", diag.IndexFiles)
+	fmt.Printf("Transcripts: %d\n", diag.Transcripts)
+	fmt.Println()
+
+	if !diag.HasProblems() {
+		fmt.Println("No problems found")
+		return 0
+	}
+
+	for _, kind := range data.ErrorKinds {
+		errs := diag.ByKind(kind)
+		if len(errs) == 0 {
+			continue
+		}
+		fmt.Printf("%s (%d)\n", kind, len(errs))
+		for _, e := range errs {
+			fmt.Printf("  %s %s\n", e.Time.Format("15:04:05"), e)
+		}
+		fmt.Println()
+	}
+	fmt.Printf("%d problems found\n", len(diag.Errors))
+	return 1
+}
+
+// runSearch prints the sessions matching a full-text search as JSON.
+// It returns the exit code: 1 if nothing matched, 2 on usage errors.
+func runSearch(newManager func() *data.Manager, args []string) int {
+	fs := flag.NewFlagSet("search", flag.ExitOnError)
+	limit := fs.Int("limit", 50, "Maximum number of sessions to list (0 for all)")
+	addClaudeDirFlag(fs)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: lazyvibe search [--limit N] <query>")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	query := strings.Join(fs.Args(), " ")
+	if strings.TrimSpace(query) == "" {
+		fs.Usage()
+		return 2
+	}
+
+	manager := newManager()
+	results := manager.Search(query, *limit)
+	manager.Close()
+	if results == nil {
+		results = []data.SearchResult{}
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(results); err != nil {
+		fmt.Fprintf(os.Stderr, "Error encoding JSON: %v\n", err)
+		return 1
+	}
+	if len(results) == 0 {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/moshe-exe/lazyvibe/internal/data"
+)
+
+// outputFormats lists the formats the list commands write.
+var outputFormats = []string{"table", "json", "csv", "tsv", "markdown"}
+
+// column is a field of the rows a list command prints.
+type column[T any] struct {
+	name  string // CSV header and JSON key
+	value func(T) any
+}
+
+// writeRows writes rows in the given format. Values may be strings,
+// numbers, times and durations; tables and Markdown show them for
+// reading, the other formats for parsing.
+func writeRows[T any](w io.Writer, format string, columns []column[T], rows []T) error {
+	cells := func(row T, human bool) []string {
+		out := make([]string, len(columns))
+		for i, c := range columns {
+			out[i] = cellText(c.value(row), human)
+		}
+		return out
+	}
+
+	switch format {
+	case "table":
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		headers := make([]string, len(columns))
+		for i, c := range columns {
+			headers[i] = strings.ToUpper(c.name)
+		}
+		fmt.Fprintln(tw, strings.Join(headers, "\t"))
+		for _, row := range rows {
+			fmt.Fprintln(tw, strings.Join(oneLine(cells(row, true), "\t"), "\t"))
+		}
+		return tw.Flush()
+
+	case "csv":
+		cw := csv.NewWriter(w)
+		headers := make([]string, len(columns))
+		for i, c := range columns {
+			headers[i] = c.name
+		}
+		cw.Write(headers)
+		for _, row := range rows {
+			cw.Write(cells(row, false))
+		}
+		cw.Flush()
+		return cw.Error()
+
+	case "tsv":
+		// No quoting: tabs, line breaks and backslashes are escaped
+		headers := make([]string, len(columns))
+		for i, c := range columns {
+			headers[i] = c.name
+		}
+		if _, err := fmt.Fprintln(w, strings.Join(headers, "\t")); err != nil {
+			return err
+		}
+		for _, row := range rows {
+			values := cells(row, false)
+			for i, v := range values {
+				values[i] = tsvEscaper.Replace(v)
+			}
+			if _, err := fmt.Fprintln(w, strings.Join(values, "\t")); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case "markdown":
+		headers := make([]string, len(columns))
+		rule := make([]string, len(columns))
+		for i, c := range columns {
+			headers[i] = c.name
+			rule[i] = "---"
+		}
+		fmt.Fprintf(w, "| %s |\n", strings.Join(headers, " | "))
+		fmt.Fprintf(w, "|%s|\n", strings.Join(rule, "|"))
+		for _, row := range rows {
+			values := oneLine(cells(row, true))
+			for i, v := range values {
+				values[i] = strings.ReplaceAll(v, "|", `\|`)
+			}
+			fmt.Fprintf(w, "| %s |\n", strings.Join(values, " | "))
+		}
+		return nil
+
+	case "json":
+		records := make([]jsonRecord, len(rows))
+		for i, row := range rows {
+			record := jsonRecord{keys: make([]string, len(columns)), values: make([]any, len(columns))}
+			for j, c := range columns {
+				record.keys[j] = c.name
+				record.values[j] = jsonValue(c.value(row))
+			}
+			records[i] = record
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(records)
+	}
+	return fmt.Errorf("unknown format %q (want %s)", format, strings.Join(outputFormats, ", "))
+}
+
+// cellText formats a value for a text cell.
+func cellText(v any, human bool) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case int:
+		return strconv.Itoa(v)
+	case float64:
+		// Only costs are fractional
+		if human {
+			return fmt.Sprintf("$%.2f", v)
+		}
+		return strconv.FormatFloat(v, 'f', 4, 64)
+	case time.Time:
+		if v.IsZero() {
+			return ""
+		}
+		if human {
+			return v.Local().Format("2006-01-02 15:04")
+		}
+		return v.Format(time.RFC3339)
+	case time.Duration:
+		if human {
+			return data.FormatDuration(v)
+		}
+		return strconv.Itoa(int(v.Seconds()))
+	}
+	return fmt.Sprint(v)
+}
+
+// tsvEscaper escapes the characters TSV fields cannot hold.
+var tsvEscaper = strings.NewReplacer(`\`, `\\`, "\t", `\t`, "\n", `\n`, "\r", `\r`)
+
+// jsonValue converts a value for JSON output: durations become seconds
+// and unknown times null.
+func jsonValue(v any) any {
+	switch v := v.(type) {
+	case time.Duration:
+		return int(v.Seconds())
+	case time.Time:
+		if v.IsZero() {
+			return nil
+		}
+	}
+	return v
+}
+
+// oneLine replaces line breaks and any separators in cells with spaces.
+func oneLine(values []string, separators ...string) []string {
+	pairs := []string{"\r\n", " ", "\n", " ", "\r", " "}
+	for _, sep := range separators {
+		pairs = append(pairs, sep, " ")
+	}
+	r := strings.NewReplacer(pairs...)
+	for i, v := range values {
+		values[i] = r.Replace(v)
+	}
+	return values
+}
+
+// jsonRecord is a JSON object that keeps its keys in column order.
+type jsonRecord struct {
+	keys   []string
+	values []any
+}
+
+func (r jsonRecord) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range r.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		k, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		v, err := json.Marshal(r.values[i])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+type testRow struct {
+	name    string
+	cost    float64
+	started time.Time
+	took    time.Duration
+}
+
+var testColumns = []column[testRow]{
+	{"name", func(r testRow) any { return r.name }},
+	{"cost", func(r testRow) any { return r.cost }},
+	{"started", func(r testRow) any { return r.started }},
+	{"duration", func(r testRow) any { return r.took }},
+}
+
+var testRows = []testRow{
+	{"plain", 1.5, time.Date(2026, 10, 5, 9, 30, 0, 0, time.UTC), 90 * time.Second},
+	{"tab\there, \"quoted\"\nand a back\\slash", 0, time.Time{}, 0},
+}
+
+func TestWriteRows(t *testing.T) {
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"csv", "name,cost,started,duration\n" +
+			"plain,1.5000,2026-10-05T09:30:00Z,90\n" +
+			"\"tab\there, \"\"quoted\"\"\nand a back\\slash\",0.0000,,0\n"},
+		{"tsv", "name\tcost\tstarted\tduration\n" +
+			"plain\t1.5000\t2026-10-05T09:30:00Z\t90\n" +
+			"tab\\there, \"quoted\"\\nand a back\\\\slash\t0.0000\t\t0\n"},
+		{"json", `[
+  {
+    "name": "plain",
+    "cost": 1.5,
+    "started": "2026-10-05T09:30:00Z",
+    "duration": 90
+  },
+  {
+    "name": "tab\there, \"quoted\"\nand a back\\slash",
+    "cost": 0,
+    "started": null,
+    "duration": 0
+  }
+]
+`},
+	}
+	for _, tt := range tests {
+		var b bytes.Buffer
+		if err := writeRows(&b, tt.format, testColumns, testRows); err != nil {
+			t.Errorf("writeRows(%s) error: %v", tt.format, err)
+			continue
+		}
+		if got := b.String(); got != tt.want {
+			t.Errorf("writeRows(%s) =\n%s\nwant\n%s", tt.format, got, tt.want)
+		}
+	}
+}
+
+func TestWriteRowsEmpty(t *testing.T) {
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"csv", "name,cost,started,duration\n"},
+		{"tsv", "name\tcost\tstarted\tduration\n"},
+		{"json", "[]\n"},
+	}
+	for _, tt := range tests {
+		var b bytes.Buffer
+		if err := writeRows(&b, tt.format, testColumns, nil); err != nil {
+			t.Errorf("writeRows(%s) error: %v", tt.format, err)
+			continue
+		}
+		if got := b.String(); got != tt.want {
+			t.Errorf("writeRows(%s) = %q, want %q", tt.format, got, tt.want)
+		}
+	}
+}
+
+func TestWriteRowsUnknownFormat(t *testing.T) {
+	var b bytes.Buffer
+	if err := writeRows(&b, "yaml", testColumns, testRows); err == nil {
+		t.Error("writeRows(yaml) succeeded, want an error")
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/moshe-exe/lazyvibe/internal/data"
+	"github.com/moshe-exe/lazyvibe/internal/report"
+)
+
+// runReport writes a usage report for a week or month to stdout.
+func runReport(newManager func() *data.Manager, args []string) int {
+	fs := flag.NewFlagSet("report", flag.ExitOnError)
+	periodFlag := fs.String("period", "week", "Length of the report: week or month")
+	from := fs.String("from", "", "First day of the report (YYYY-MM-DD); defaults to the start of the current week or month")
+	format := fs.String("format", "markdown", "Output format: markdown or html")
+	addClaudeDirFlag(fs)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: lazyvibe report [flags]\n\n")
+		fmt.Fprintf(fs.Output(), "Write a usage report for a week or month, compared with the one before.\n")
+		fmt.Fprintf(fs.Output(), "The same data always gives the same report.\n\n")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	fail := func(err error) int {
+		fmt.Fprintf(os.Stderr, "Error: %v\n\n", err)
+		fs.Usage()
+		return 2
+	}
+	if fs.NArg() > 0 {
+		return fail(fmt.Errorf("unexpected argument %q", fs.Arg(0)))
+	}
+
+	kind, err := report.ParseKind(*periodFlag)
+	if err != nil {
+		return fail(err)
+	}
+	start := report.DefaultStart(kind, time.Now())
+	if *from != "" {
+		if start, err = time.ParseInLocation("2006-01-02", *from, time.Local); err != nil {
+			return fail(fmt.Errorf("invalid start %q (want YYYY-MM-DD)", *from))
+		}
+	}
+	write := report.WriteMarkdown
+	switch strings.ToLower(*format) {
+	case "markdown", "md":
+	case "html":
+		write = report.WriteHTML
+	default:
+		return fail(fmt.Errorf("unknown format %q (want markdown or html)", *format))
+	}
+
+	manager := newManager()
+	manager.LoadCommits()
+	dashData := loadData(manager)
+	if err := write(os.Stdout, report.Build(&dashData, kind, start)); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
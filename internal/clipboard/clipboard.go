@@ -0,0 +1,150 @@
+// Package clipboard copies text to the system clipboard. It writes OSC 52
+// escape sequences to the controlling terminal, which turns them into a
+// clipboard update even over SSH, and runs the platform's clipboard tool
+// when one is usable.
+package clipboard
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/aymanbagabas/go-osc52/v2"
+)
+
+// Methods understood by New. Any other value is a command line that
+// receives the text on stdin, e.g. "xclip -selection clipboard".
+const (
+	MethodAuto  = "auto"  // OSC 52, then the first available tool
+	MethodOSC52 = "osc52" // OSC 52 only
+	MethodTool  = "tool"  // First available tool only
+)
+
+// tool is a clipboard command and the condition for it to be usable.
+type tool struct {
+	args   []string
+	usable func() bool
+}
+
+// tools lists clipboard commands in order of preference.
+var tools = []tool{
+	{[]string{"wl-copy"}, func() bool { return os.Getenv("WAYLAND_DISPLAY") != "" }},
+	{[]string{"xclip", "-selection", "clipboard"}, func() bool { return os.Getenv("DISPLAY") != "" }},
+	{[]string{"xsel", "--clipboard", "--input"}, func() bool { return os.Getenv("DISPLAY") != "" }},
+	{[]string{"pbcopy"}, func() bool { return runtime.GOOS == "darwin" }},
+}
+
+// ttyPath is the controlling terminal. OSC 52 sequences bypass stdout,
+// which the running program draws on.
+const ttyPath = "/dev/tty"
+
+// Clipboard copies text using a configured method.
+type Clipboard struct {
+	method string
+}
+
+// New returns a clipboard using method, one of the Method constants or a
+// command line. An empty method is MethodAuto.
+func New(method string) *Clipboard {
+	method = strings.TrimSpace(method)
+	if method == "" {
+		method = MethodAuto
+	}
+	return &Clipboard{method: method}
+}
+
+// Copy copies text and returns how it was copied, e.g. "OSC 52 + xclip".
+func (c *Clipboard) Copy(text string) (string, error) {
+	switch c.method {
+	case MethodOSC52:
+		if err := c.copyOSC52(text); err != nil {
+			return "", err
+		}
+		return "OSC 52", nil
+	case MethodTool:
+		return copyTool(text)
+	case MethodAuto:
+		var used []string
+		if err := c.copyOSC52(text); err == nil {
+			used = append(used, "OSC 52")
+		}
+		if name, err := copyTool(text); err == nil {
+			used = append(used, name)
+		}
+		if len(used) == 0 {
+			return "", errors.New("no clipboard available")
+		}
+		return strings.Join(used, " + "), nil
+	default:
+		args := strings.Fields(c.method)
+		if err := runCopy(args, text); err != nil {
+			return "", err
+		}
+		return args[0], nil
+	}
+}
+
+// copyOSC52 asks the terminal to set the clipboard, wrapping the sequence
+// for tmux and screen so they pass it on.
+func (c *Clipboard) copyOSC52(text string) error {
+	if os.Getenv("TERM") == "dumb" {
+		return errors.New("not a terminal")
+	}
+	tty, err := os.OpenFile(ttyPath, os.O_WRONLY, 0)
+	if err != nil {
+		return errors.New("not a terminal")
+	}
+	defer tty.Close()
+	if !isTerminal(tty) {
+		return errors.New("not a terminal")
+	}
+
+	seq := osc52.New(text)
+	switch {
+	case os.Getenv("TMUX") != "":
+		seq = seq.Tmux()
+	case strings.HasPrefix(os.Getenv("TERM"), "screen"):
+		seq = seq.Screen()
+	}
+	_, err = seq.WriteTo(tty)
+	return err
+}
+
+// copyTool copies text with the first usable clipboard tool and returns
+// its name.
+func copyTool(text string) (string, error) {
+	for _, t := range tools {
+		if !t.usable() {
+			continue
+		}
+		if _, err := exec.LookPath(t.args[0]); err != nil {
+			continue
+		}
+		if err := runCopy(t.args, text); err != nil {
+			return "", err
+		}
+		return t.args[0], nil
+	}
+	return "", errors.New("no clipboard tool found")
+}
+
+// runCopy runs a clipboard command with text on stdin. Output is not
+// captured: wl-copy and xclip stay in the background serving the
+// selection, and reading their output would wait for them to exit.
+func runCopy(args []string, text string) error {
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdin = strings.NewReader(text)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s: %w", args[0], err)
+	}
+	return nil
+}
+
+// isTerminal reports whether f is a character device such as a terminal.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
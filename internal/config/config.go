@@ -14,18 +14,84 @@ type Config struct {
 	RefreshInterval  int    `toml:"refresh_interval"` // seconds
 	DefaultTimeRange string `toml:"default_time_range"`
 	ShowScrollbar    bool   `toml:"show_scrollbar"`
+
+	// WeekStart is the first day of calendar weeks, e.g. "monday" or
+	// "sunday".
+	WeekStart string `toml:"week_start"`
+
+	// ClaudeDirs lists the Claude Code data directories to read and merge,
+	// e.g. separate profiles or teammates' synced directories.
+	ClaudeDirs []string `toml:"claude_dirs"`
+
+	// Watch refreshes as soon as Claude data files change. Polling every
+	// RefreshInterval remains the fallback when watching is unavailable.
+	Watch bool `toml:"watch"`
+
+	// VMProcesses lists process name fragments identifying the VM or
+	// container processes shown in the header. Empty uses the platform
+	// default.
+	VMProcesses []string `toml:"vm_processes"`
+
+	// Pricing overrides or extends the built-in model price table, which
+	// PriceTable merges it with.
+	Pricing PriceOverrides `toml:"pricing"`
+
+	// ResumeCommand resumes a session from the Sessions panel, run in the
+	// session's project directory. {id} and {project} are replaced by the
+	// session ID and project path.
+	ResumeCommand string `toml:"resume_command"`
+
+	// EditorCommand opens a project. Empty uses $VISUAL or $EDITOR.
+	EditorCommand string `toml:"editor_command"`
+
+	// Clipboard selects how text is copied: "auto" (OSC 52, then a
+	// clipboard tool), "osc52", "tool", or a command reading stdin.
+	Clipboard string `toml:"clipboard"`
+
+	// CommitGraceMinutes extends each session when linking it to the git
+	// commits made while it ran, to catch commits made just after.
+	CommitGraceMinutes int `toml:"commit_grace_minutes"`
 }
 
 // DefaultConfig returns the default configuration.
 func DefaultConfig() *Config {
 	return &Config{
-		Theme:            "default",
-		RefreshInterval:  10,
-		DefaultTimeRange: "all",
-		ShowScrollbar:    true,
+		Theme:              "default",
+		RefreshInterval:    10,
+		DefaultTimeRange:   "all",
+		ShowScrollbar:      true,
+		WeekStart:          "monday",
+		ClaudeDirs:         []string{"~/.claude"},
+		Watch:              true,
+		ResumeCommand:      "claude --resume {id}",
+		Clipboard:          "auto",
+		CommitGraceMinutes: 30,
 	}
 }
 
+// PriceTable returns the built-in model prices with the configured
+// overrides applied.
+func (c *Config) PriceTable() PriceTable {
+	return c.Pricing.Apply(DefaultPricing())
+}
+
+// claudeConfigDirEnv overrides the Claude data directories with the single
+// directory Claude Code itself reads it as.
+const claudeConfigDirEnv = "CLAUDE_CONFIG_DIR"
+
+// applyEnv applies environment variable overrides.
+func (c *Config) applyEnv() {
+	if dir := os.Getenv(claudeConfigDirEnv); dir != "" {
+		c.ClaudeDirs = []string{dir}
+	}
+}
+
+// Path returns the path to the config file, or "" if the home
+// directory is unknown.
+func Path() string {
+	return configPath()
+}
+
 // configPath returns the path to the config file.
 func configPath() string {
 	home, err := os.UserHomeDir()
@@ -36,7 +102,15 @@ func configPath() string {
 }
 
 // Load loads the configuration from file, creating defaults if needed.
+// Environment variables override the file.
 func Load() (*Config, error) {
+	cfg, err := load()
+	cfg.applyEnv()
+	return cfg, err
+}
+
+// load reads the configuration file.
+func load() (*Config, error) {
 	cfg := DefaultConfig()
 	path := configPath()
 	if path == "" {
@@ -0,0 +1,94 @@
+package config
+
+import "strings"
+
+// ModelPrice holds USD prices per million tokens for a model.
+type ModelPrice struct {
+	Input      float64 `toml:"input"`
+	Output     float64 `toml:"output"`
+	CacheWrite float64 `toml:"cache_write"`
+	CacheRead  float64 `toml:"cache_read"`
+}
+
+// Cost returns the USD cost of the given token counts.
+func (p ModelPrice) Cost(input, output, cacheWrite, cacheRead int) float64 {
+	return (float64(input)*p.Input +
+		float64(output)*p.Output +
+		float64(cacheWrite)*p.CacheWrite +
+		float64(cacheRead)*p.CacheRead) / 1000000
+}
+
+// PriceTable maps model name fragments to prices.
+// A model matches the longest key contained in its name,
+// so "opus-4-5" takes precedence over "opus". Of keys as long,
+// the one that sorts first wins.
+type PriceTable map[string]ModelPrice
+
+// DefaultPricing returns the built-in price table.
+func DefaultPricing() PriceTable {
+	return PriceTable{
+		"opus":      {Input: 15, Output: 75, CacheWrite: 18.75, CacheRead: 1.50},
+		"opus-4-5":  {Input: 5, Output: 25, CacheWrite: 6.25, CacheRead: 0.50},
+		"sonnet":    {Input: 3, Output: 15, CacheWrite: 3.75, CacheRead: 0.30},
+		"haiku":     {Input: 0.80, Output: 4, CacheWrite: 1, CacheRead: 0.08},
+		"haiku-4-5": {Input: 1, Output: 5, CacheWrite: 1.25, CacheRead: 0.10},
+	}
+}
+
+// PriceOverride sets some of a model's prices in the config file. Prices
+// left out keep those the model name fragment would get without it.
+type PriceOverride struct {
+	Input      *float64 `toml:"input"`
+	Output     *float64 `toml:"output"`
+	CacheWrite *float64 `toml:"cache_write"`
+	CacheRead  *float64 `toml:"cache_read"`
+}
+
+// PriceOverrides maps model name fragments to overrides, as PriceTable
+// maps them to prices.
+type PriceOverrides map[string]PriceOverride
+
+// Apply returns a copy of t with the overrides merged in price by price.
+// A new fragment starts from the prices of the fragment it contains, e.g.
+// "opus-5" from "opus".
+func (o PriceOverrides) Apply(t PriceTable) PriceTable {
+	merged := make(PriceTable, len(t)+len(o))
+	for key, price := range t {
+		merged[key] = price
+	}
+	for key, override := range o {
+		price, _ := t.Lookup(key)
+		if override.Input != nil {
+			price.Input = *override.Input
+		}
+		if override.Output != nil {
+			price.Output = *override.Output
+		}
+		if override.CacheWrite != nil {
+			price.CacheWrite = *override.CacheWrite
+		}
+		if override.CacheRead != nil {
+			price.CacheRead = *override.CacheRead
+		}
+		merged[key] = price
+	}
+	return merged
+}
+
+// Lookup returns the price for a model name.
+func (t PriceTable) Lookup(model string) (ModelPrice, bool) {
+	model = strings.ToLower(model)
+	var best string
+	for key := range t {
+		if !strings.Contains(model, strings.ToLower(key)) {
+			continue
+		}
+		if len(key) > len(best) || len(key) == len(best) && key < best {
+			best = key
+		}
+	}
+	if best == "" {
+		return ModelPrice{}, false
+	}
+	return t[best], true
+}
@@ -0,0 +1,73 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLookup(t *testing.T) {
+	table := PriceTable{
+		"opus":     {Input: 15},
+		"opus-4-5": {Input: 5},
+		"Sonnet":   {Input: 3},
+		"4-opus":   {Input: 1},
+		"opus-4":   {Input: 2},
+	}
+	tests := []struct {
+		model  string
+		want   float64
+		wantOK bool
+	}{
+		{"claude-opus-4-5-20251101", 5, true}, // Longest match
+		{"claude-opus-4-1-20250805", 2, true},
+		{"claude-3-opus-20240229", 15, true},
+		{"CLAUDE-SONNET-4-5", 3, true}, // Ignoring case
+		{"claude-4-opus-4", 1, true},   // Tie: "4-opus" sorts before "opus-4"
+		{"gpt-4o", 0, false},
+		{"", 0, false},
+	}
+	for _, tt := range tests {
+		// Repeat to catch results that depend on map order
+		for i := 0; i < 20; i++ {
+			got, ok := table.Lookup(tt.model)
+			if ok != tt.wantOK || got.Input != tt.want {
+				t.Errorf("Lookup(%q) = %v, %v, want input %v, %v", tt.model, got, ok, tt.want, tt.wantOK)
+				break
+			}
+		}
+	}
+}
+
+func TestPriceOverridesApply(t *testing.T) {
+	price := func(v float64) *float64 { return &v }
+	table := PriceTable{
+		"opus":   {Input: 15, Output: 75, CacheWrite: 18.75, CacheRead: 1.5},
+		"sonnet": {Input: 3, Output: 15, CacheWrite: 3.75, CacheRead: 0.3},
+	}
+	overrides := PriceOverrides{
+		"sonnet": {Output: price(12)},                 // Only the output price
+		"opus-5": {Input: price(4)},                   // New fragment, from "opus"
+		"gpt":    {Input: price(2), Output: price(8)}, // Unknown, from zero
+	}
+
+	got := overrides.Apply(table)
+	want := PriceTable{
+		"opus":   {Input: 15, Output: 75, CacheWrite: 18.75, CacheRead: 1.5},
+		"sonnet": {Input: 3, Output: 12, CacheWrite: 3.75, CacheRead: 0.3},
+		"opus-5": {Input: 4, Output: 75, CacheWrite: 18.75, CacheRead: 1.5},
+		"gpt":    {Input: 2, Output: 8},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Apply() = %v, want %v", got, want)
+	}
+	if table["sonnet"].Output != 15 || len(table) != 2 {
+		t.Errorf("Apply() modified the table: %v", table)
+	}
+
+	if p, _ := got.Lookup("claude-opus-5"); p.Input != 4 {
+		t.Errorf("Lookup(claude-opus-5) = %v, want the override", p)
+	}
+	if p, _ := PriceOverrides(nil).Apply(DefaultPricing()).Lookup("claude-haiku-4-5"); p != DefaultPricing()["haiku-4-5"] {
+		t.Errorf("no overrides: Lookup(claude-haiku-4-5) = %v, want the default", p)
+	}
+}
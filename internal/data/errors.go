@@ -0,0 +1,118 @@
+package data
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"time"
+)
+
+// ErrorKind classifies a data problem.
+type ErrorKind int
+
+const (
+	ErrorUnreadable ErrorKind = iota // File or directory could not be read or decoded
+	ErrorMalformed                   // Line or entry could not be decoded
+	ErrorTimestamp                   // Timestamp could not be parsed and is treated as unknown
+	ErrorConfig                      // Configuration file could not be loaded
+	ErrorCache                       // Cache file could not be written
+)
+
+// ErrorKinds lists every kind in report order.
+var ErrorKinds = []ErrorKind{ErrorConfig, ErrorCache, ErrorUnreadable, ErrorMalformed, ErrorTimestamp}
+
+// String returns a display name for the kind.
+func (k ErrorKind) String() string {
+	switch k {
+	case ErrorMalformed:
+		return "Malformed entry"
+	case ErrorTimestamp:
+		return "Bad timestamp"
+	case ErrorConfig:
+		return "Config error"
+	case ErrorCache:
+		return "Cache error"
+	default:
+		return "Unreadable file"
+	}
+}
+
+// ParseError describes a data file, or a line of one, that could not be parsed.
+type ParseError struct {
+	Kind    ErrorKind
+	Path    string
+	Line    int // 1-based line number, 0 for the whole file
+	Message string
+	Time    time.Time // When the problem was found
+}
+
+// newParseError returns a ParseError found now.
+func newParseError(kind ErrorKind, path string, line int, message string) ParseError {
+	return ParseError{Kind: kind, Path: path, Line: line, Message: message, Time: time.Now()}
+}
+
+// errorText returns the message of err without the path that file
+// errors repeat, since ParseError already records it.
+func errorText(err error) string {
+	var pathErr *fs.PathError
+	if errors.As(err, &pathErr) {
+		return pathErr.Err.Error()
+	}
+	return err.Error()
+}
+
+// Error implements the error interface.
+func (e ParseError) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("%s:%d: %s", e.Path, e.Line, e.Message)
+	}
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// Diagnostics summarizes the data read during the latest refresh and the
+// problems found in it.
+type Diagnostics struct {
+	Roots       []string // Claude data directories read
+	IndexFiles  int      // sessions-index.json files found
+	Transcripts int      // Transcript files found
+	Errors      []ParseError
+}
+
+// HasProblems returns whether any problem was found.
+func (d Diagnostics) HasProblems() bool {
+	return len(d.Errors) > 0
+}
+
+// ByKind returns the problems of the given kind.
+func (d Diagnostics) ByKind(kind ErrorKind) []ParseError {
+	var errs []ParseError
+	for _, e := range d.Errors {
+		if e.Kind == kind {
+			errs = append(errs, e)
+		}
+	}
+	return errs
+}
+
+// Diagnostics reports on the data read by the latest refresh. Missing
+// Claude data directories are reported as unreadable.
+func (m *Manager) Diagnostics() Diagnostics {
+	m.mu.RLock()
+	diag := Diagnostics{
+		Roots:       m.roots,
+		IndexFiles:  m.indexCount,
+		Transcripts: m.transcriptCount,
+	}
+	m.mu.RUnlock()
+
+	for _, root := range m.roots {
+		if info, err := os.Stat(root); err != nil {
+			diag.Errors = append(diag.Errors, newParseError(ErrorUnreadable, root, 0, errorText(err)))
+		} else if !info.IsDir() {
+			diag.Errors = append(diag.Errors, newParseError(ErrorUnreadable, root, 0, "not a directory"))
+		}
+	}
+	diag.Errors = append(diag.Errors, m.ParseErrors()...)
+	return diag
+}
@@ -0,0 +1,270 @@
+package data
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// GitTTL is how long a repository's commit log is reused before git is
+// run again.
+const GitTTL = time.Minute
+
+// gitTimeout bounds a single git command.
+const gitTimeout = 10 * time.Second
+
+// Commit is a git commit in a project's repository.
+type Commit struct {
+	Hash    string
+	Subject string
+	Author  string
+	Time    time.Time // Author date
+	Added   int       // Lines added; binary files are not counted
+	Removed int       // Lines removed
+}
+
+// GitActivity links a session to the commits authored while it ran.
+type GitActivity struct {
+	Commits []Commit // Oldest first
+	Added   int
+	Removed int
+}
+
+// gitLogKey identifies the commit log of a branch. An empty branch is HEAD.
+type gitLogKey struct {
+	repo   string
+	branch string
+}
+
+// gitLog is a cached commit log reaching back to since.
+type gitLog struct {
+	since   time.Time
+	commits []Commit // Newest first, as git prints them
+	err     error    // Set if the directory is not a readable repository
+	loaded  time.Time
+}
+
+// gitIndex caches commit logs of the repositories sessions ran in. Logs
+// are read in the background so refreshes never wait for git.
+type gitIndex struct {
+	mu      sync.Mutex
+	logs    map[gitLogKey]gitLog
+	loading chan struct{} // Closed when the running load finishes, nil if none
+}
+
+// attach sets the Git activity of each session from the cached logs: the
+// commits on its branch authored between its start and grace after its
+// last activity. Sessions whose project is not a readable git repository,
+// whose times are unknown, or whose log is not read yet are left without
+// activity. It returns the logs that are missing or stale, each with how
+// far back it must reach.
+func (g *gitIndex) attach(sessions []SessionEntry, grace time.Duration) map[gitLogKey]time.Time {
+	// Each branch must reach back to its earliest session
+	since := make(map[gitLogKey]time.Time)
+	for _, s := range sessions {
+		key, ok := sessionLogKey(s)
+		if !ok {
+			continue
+		}
+		if start, seen := since[key]; !seen || s.Created.Before(start) {
+			since[key] = s.Created
+		}
+	}
+
+	logs := make(map[gitLogKey]gitLog, len(since))
+	stale := make(map[gitLogKey]time.Time)
+	g.mu.Lock()
+	for key, start := range since {
+		cached, ok := g.logs[key]
+		if !ok || time.Since(cached.loaded) >= GitTTL || start.Before(cached.since) {
+			stale[key] = start
+		}
+		if ok {
+			logs[key] = cached
+		}
+	}
+	g.mu.Unlock()
+
+	for i := range sessions {
+		sessions[i].Git = nil
+		key, ok := sessionLogKey(sessions[i])
+		log, cached := logs[key]
+		if !ok || !cached || log.err != nil {
+			continue
+		}
+		activity := &GitActivity{}
+		end := sessions[i].Modified.Add(grace)
+		for _, c := range log.commits {
+			if c.Time.Before(sessions[i].Created) || c.Time.After(end) {
+				continue
+			}
+			activity.Commits = append(activity.Commits, c)
+			activity.Added += c.Added
+			activity.Removed += c.Removed
+		}
+		sort.Slice(activity.Commits, func(a, b int) bool {
+			return activity.Commits[a].Time.Before(activity.Commits[b].Time)
+		})
+		sessions[i].Git = activity
+	}
+	return stale
+}
+
+// sessionLogKey returns the commit log a session's commits are found in.
+func sessionLogKey(s SessionEntry) (gitLogKey, bool) {
+	if s.ProjectPath == "" || !s.TimesKnown() {
+		return gitLogKey{}, false
+	}
+	key := gitLogKey{repo: s.ProjectPath}
+	if s.GitBranch != nil && *s.GitBranch != "HEAD" {
+		key.branch = *s.GitBranch
+	}
+	return key, true
+}
+
+// loadAsync reads the given logs in the background and calls done once
+// they are cached. It does nothing while a load is running; the next
+// attach asks again for whatever that load leaves stale.
+func (g *gitIndex) loadAsync(logs map[gitLogKey]time.Time, done func()) {
+	g.mu.Lock()
+	if g.loading != nil {
+		g.mu.Unlock()
+		return
+	}
+	loading := make(chan struct{})
+	g.loading = loading
+	g.mu.Unlock()
+
+	go func() {
+		for key, since := range logs {
+			commits, err := readGitLog(key.repo, key.branch, since)
+			g.mu.Lock()
+			if g.logs == nil {
+				g.logs = make(map[gitLogKey]gitLog)
+			}
+			g.logs[key] = gitLog{since: since, commits: commits, err: err, loaded: time.Now()}
+			g.mu.Unlock()
+		}
+		done()
+
+		g.mu.Lock()
+		g.loading = nil
+		g.mu.Unlock()
+		close(loading)
+	}()
+}
+
+// wait blocks until the running load, if any, finishes.
+func (g *gitIndex) wait() {
+	g.mu.Lock()
+	loading := g.loading
+	g.mu.Unlock()
+	if loading != nil {
+		<-loading
+	}
+}
+
+// readGitLog runs git log in repo for the commits of branch committed
+// since the given time. A branch that no longer exists, e.g. deleted after
+// merging, has no commits: other branches' commits were not made in the
+// session.
+func readGitLog(repo, branch string, since time.Time) ([]Commit, error) {
+	if !inWorkTree(repo) {
+		return nil, errNotWorkTree
+	}
+	ref := "HEAD"
+	if branch != "" {
+		if _, err := runGit(repo, "rev-parse", "--git-dir"); err != nil {
+			return nil, err
+		}
+		ref = "refs/heads/" + branch
+		if _, err := runGit(repo, "rev-parse", "--verify", "--quiet", ref+"^{commit}"); err != nil {
+			return nil, nil
+		}
+	}
+
+	out, err := runGit(repo, "log", ref, "--no-merges", "--numstat",
+		"--since="+since.Format(time.RFC3339),
+		"--format=%x1e%H%x1f%at%x1f%an%x1f%s")
+	if err != nil {
+		return nil, err
+	}
+	return parseGitLog(out), nil
+}
+
+// errNotWorkTree is the error of directories outside any git working tree.
+var errNotWorkTree = errors.New("not a git working tree")
+
+// inWorkTree reports whether dir exists and it or a parent holds a .git
+// directory or file, so that git is not run for every directory sessions
+// ran in.
+func inWorkTree(dir string) bool {
+	if !filepath.IsAbs(dir) {
+		return false
+	}
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return false
+	}
+	for dir = filepath.Clean(dir); ; dir = filepath.Dir(dir) {
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return true
+		}
+		if filepath.Dir(dir) == dir {
+			return false
+		}
+	}
+}
+
+// runGit runs a git command in dir and returns its output.
+func runGit(dir string, args ...string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), gitTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", dir}, args...)...)
+	return cmd.Output()
+}
+
+// parseGitLog parses git log output of records starting with a record
+// separator, each a header line of unit-separated fields followed by
+// numstat lines.
+func parseGitLog(out []byte) []Commit {
+	var commits []Commit
+	for _, record := range bytes.Split(out, []byte{0x1e}) {
+		scanner := bufio.NewScanner(bytes.NewReader(record))
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+		if !scanner.Scan() {
+			continue
+		}
+		fields := strings.SplitN(scanner.Text(), "\x1f", 4)
+		if len(fields) != 4 {
+			continue
+		}
+		epoch, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		c := Commit{Hash: fields[0], Time: time.Unix(epoch, 0), Author: fields[2], Subject: fields[3]}
+
+		// Numstat lines: added, removed and path, "-" for binary files
+		for scanner.Scan() {
+			parts := strings.SplitN(scanner.Text(), "\t", 3)
+			if len(parts) != 3 {
+				continue
+			}
+			added, _ := strconv.Atoi(parts[0])
+			removed, _ := strconv.Atoi(parts[1])
+			c.Added += added
+			c.Removed += removed
+		}
+		commits = append(commits, c)
+	}
+	return commits
+}
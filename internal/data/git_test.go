@@ -0,0 +1,68 @@
+package data
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseGitLog(t *testing.T) {
+	// As printed by git log --numstat --format=%x1e%H%x1f%at%x1f%an%x1f%s
+	out := "\x1eaaa111\x1f1791192600\x1fAnn Lee\x1fRename\tfiles, and \x1f more\n\n" +
+		"3\t1\tsrc/{old.go => new.go}\n" +
+		"0\t0\tREADME => README.md\n" +
+		"-\t-\tlogo.png\n" +
+		"10\t2\tmain.go\n" +
+		"\x1ebbb222\x1f1791190000\x1fBo\x1fEmpty commit\n" +
+		"\x1eccc333\x1fnot a time\x1fBo\x1fSkipped\n\n1\t1\tx.go\n" +
+		"\x1eddd444\x1f1791180000\x1fBo\n" +
+		"\x1eeee555\x1f1791170000\x1fCy\x1fAdd\n\n5\t0\ta.go\n"
+
+	want := []Commit{
+		{Hash: "aaa111", Time: time.Unix(1791192600, 0), Author: "Ann Lee", Subject: "Rename\tfiles, and \x1f more", Added: 13, Removed: 3},
+		{Hash: "bbb222", Time: time.Unix(1791190000, 0), Author: "Bo", Subject: "Empty commit"},
+		{Hash: "eee555", Time: time.Unix(1791170000, 0), Author: "Cy", Subject: "Add", Added: 5},
+	}
+	if got := parseGitLog([]byte(out)); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseGitLog() =\n%+v\nwant\n%+v", got, want)
+	}
+	if got := parseGitLog(nil); len(got) != 0 {
+		t.Errorf("parseGitLog(nil) = %+v, want none", got)
+	}
+}
+
+func TestInWorkTree(t *testing.T) {
+	dir := t.TempDir()
+	repo := filepath.Join(dir, "repo")
+	worktree := filepath.Join(dir, "worktree")
+	plain := filepath.Join(dir, "plain")
+	for _, d := range []string{filepath.Join(repo, ".git"), filepath.Join(repo, "src", "pkg"), worktree, plain} {
+		if err := os.MkdirAll(d, 0o700); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Linked worktrees and submodules have a .git file
+	if err := os.WriteFile(filepath.Join(worktree, ".git"), []byte("gitdir: ../repo/.git\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		dir  string
+		want bool
+	}{
+		{repo, true},
+		{filepath.Join(repo, "src", "pkg"), true},
+		{worktree, true},
+		{plain, false},
+		{filepath.Join(repo, "deleted"), false},
+		{"repo", false}, // Relative, as for projects named after their directory
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := inWorkTree(tt.dir); got != tt.want {
+			t.Errorf("inWorkTree(%q) = %v, want %v", tt.dir, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,279 @@
+package data
+
+import (
+	"encoding/json"
+	"hash/fnv"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// ingestCacheName is the ingestion cache's file name in the cache directory.
+const ingestCacheName = "transcripts.json"
+
+// ingestCacheVersion is bumped whenever the aggregates change shape,
+// discarding caches written by older versions.
+const ingestCacheVersion = 6
+
+// ingestSaveInterval limits how often the ingestion cache and the search
+// index are rewritten on disk.
+const ingestSaveInterval = 30 * time.Second
+
+// headSize is how much of the start of a transcript is hashed to tell a
+// file that grew from one that was replaced.
+const headSize = 4096
+
+// cachePath returns the path of a file in lazyvibe's directory under the
+// user cache directory, or "" if that is unknown, so that caches are kept
+// in memory rather than written relative to the working directory.
+func cachePath(name string) string {
+	dir, err := os.UserCacheDir()
+	if err != nil || !filepath.IsAbs(dir) {
+		return ""
+	}
+	return filepath.Join(dir, "lazyvibe", name)
+}
+
+// transcriptState records how far a transcript has been ingested.
+type transcriptState struct {
+	Offset  int64     `json:"offset"`
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"mtime"`
+	Head    uint64    `json:"head"` // Hash of the file's first bytes read
+
+	Stats         TranscriptStats        `json:"stats"`
+	Lines         int                    `json:"lines"`
+	PendingTools  map[string]pendingTool `json:"pendingTools,omitempty"`
+	LastMessageID string                 `json:"lastMessageId,omitempty"`
+}
+
+// ingestCacheFile is the on-disk format of the ingestion cache.
+type ingestCacheFile struct {
+	Version int                         `json:"version"`
+	Files   map[string]*transcriptState `json:"files"`
+}
+
+// transcriptIndex ingests transcripts incrementally. Only lines appended
+// since the last read are parsed, and progress is persisted so a restart
+// resumes where the previous run stopped.
+type transcriptIndex struct {
+	mu    sync.Mutex
+	path  string // Cache file, empty to keep state in memory only
+	files map[string]*transcriptState
+	dirty bool
+	saved time.Time
+}
+
+// newTranscriptIndex creates an index backed by the cache file at path.
+// A missing, unreadable or outdated cache starts the index empty.
+func newTranscriptIndex(path string) *transcriptIndex {
+	x := &transcriptIndex{
+		path:  path,
+		files: make(map[string]*transcriptState),
+	}
+	if path == "" {
+		return x
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return x
+	}
+	var cache ingestCacheFile
+	if err := json.Unmarshal(content, &cache); err != nil || cache.Version != ingestCacheVersion {
+		return x
+	}
+	for fpath, st := range cache.Files {
+		if st == nil {
+			continue
+		}
+		st.Stats.Path = fpath
+		x.files[fpath] = st
+	}
+	return x
+}
+
+// load returns stats for the given transcripts, reading only what was
+// appended to each file since it was last seen. Files that shrank or
+// whose start changed are read again from the start. Files that cannot
+// be read, and a cache that cannot be saved, are returned as errors.
+func (x *transcriptIndex) load(paths []string) ([]TranscriptStats, []ParseError) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	type job struct {
+		path  string
+		info  os.FileInfo
+		state *transcriptState
+	}
+
+	seen := make(map[string]bool, len(paths))
+	var jobs []job
+	for _, fpath := range paths {
+		info, err := os.Stat(fpath)
+		if err != nil {
+			continue
+		}
+		seen[fpath] = true
+
+		st := x.files[fpath]
+		if st != nil && st.Size == info.Size() && st.ModTime.Equal(info.ModTime()) {
+			continue
+		}
+		jobs = append(jobs, job{path: fpath, info: info, state: st})
+	}
+
+	// Parse changed files in parallel; a cold start may read gigabytes
+	results := make([]*transcriptState, len(jobs))
+	errs := make([]error, len(jobs))
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, runtime.GOMAXPROCS(0))
+	for i, j := range jobs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, j job) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i], errs[i] = ingestTranscript(j.path, j.info, j.state)
+		}(i, j)
+	}
+	wg.Wait()
+
+	var failed []ParseError
+	for i, j := range jobs {
+		if errs[i] != nil {
+			delete(x.files, j.path)
+			failed = append(failed, newParseError(ErrorUnreadable, j.path, 0, errorText(errs[i])))
+		} else {
+			x.files[j.path] = results[i]
+		}
+		x.dirty = true
+	}
+
+	// Forget files that no longer exist
+	for fpath := range x.files {
+		if !seen[fpath] {
+			delete(x.files, fpath)
+			x.dirty = true
+		}
+	}
+
+	if x.dirty && time.Since(x.saved) >= ingestSaveInterval {
+		if err := x.saveLocked(); err != nil {
+			failed = append(failed, newParseError(ErrorCache, x.path, 0, errorText(err)))
+		}
+	}
+
+	stats := make([]TranscriptStats, 0, len(x.files))
+	for _, fpath := range paths {
+		if st, ok := x.files[fpath]; ok {
+			stats = append(stats, st.Stats)
+		}
+	}
+	return stats, failed
+}
+
+// ingestTranscript continues ingesting a transcript from prev, or from
+// the start when prev is nil or the file was truncated or replaced. The
+// previous state is not modified, so stats handed out earlier stay
+// unchanged.
+func ingestTranscript(fpath string, info os.FileInfo, prev *transcriptState) (*transcriptState, error) {
+	stats := newTranscriptStats(fpath)
+	var offset int64
+	if prev != nil && sameStart(fpath, info, prev.Offset, prev.Head) {
+		stats = prev.Stats.clone()
+		for id, tool := range prev.PendingTools {
+			stats.pendingTools[id] = tool
+		}
+		stats.lastMessageID = prev.LastMessageID
+		stats.lines = prev.Lines
+		offset = prev.Offset
+	}
+
+	offset, err := stats.readFrom(fpath, offset)
+	if err != nil {
+		return nil, err
+	}
+	head, err := fileHead(fpath, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return &transcriptState{
+		Offset:        offset,
+		Size:          info.Size(),
+		ModTime:       info.ModTime(),
+		Head:          head,
+		Stats:         stats,
+		Lines:         stats.lines,
+		PendingTools:  stats.pendingTools,
+		LastMessageID: stats.lastMessageID,
+	}, nil
+}
+
+// sameStart returns whether a file read up to offset still starts as it
+// did, as hashed by fileHead, so that reading can resume at offset.
+func sameStart(fpath string, info os.FileInfo, offset int64, head uint64) bool {
+	if info.Size() < offset {
+		return false
+	}
+	h, err := fileHead(fpath, offset)
+	return err == nil && h == head
+}
+
+// fileHead hashes the first bytes of a file, up to headSize but no more
+// than the offset read so far, since later bytes may still change.
+func fileHead(fpath string, offset int64) (uint64, error) {
+	f, err := os.Open(fpath)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	h := fnv.New64a()
+	if _, err := io.CopyN(h, f, min(offset, headSize)); err != nil && err != io.EOF {
+		return 0, err
+	}
+	return h.Sum64(), nil
+}
+
+// save writes the cache file if anything changed since the last save.
+func (x *transcriptIndex) save() error {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	if !x.dirty {
+		return nil
+	}
+	return x.saveLocked()
+}
+
+// saveLocked atomically replaces the cache file. It holds prompts,
+// summaries and file paths, so only the user may read it. The caller
+// holds x.mu.
+func (x *transcriptIndex) saveLocked() error {
+	x.saved = time.Now()
+	if x.path == "" {
+		x.dirty = false
+		return nil
+	}
+
+	content, err := json.Marshal(ingestCacheFile{Version: ingestCacheVersion, Files: x.files})
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(x.path), 0700); err != nil {
+		return err
+	}
+	tmp := x.path + ".tmp"
+	if err := os.WriteFile(tmp, content, 0600); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, x.path); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	x.dirty = false
+	return nil
+}
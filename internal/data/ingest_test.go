@@ -0,0 +1,106 @@
+package data
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Lines of a session whose streamed message m1 and its tool call are
+// split across two ingestions.
+const (
+	resumePrompt = `{"type":"user","sessionId":"s1","timestamp":"2026-10-05T09:00:00Z","message":{"role":"user","content":"hi"}}` + "\n"
+	resumeTool   = `{"type":"assistant","sessionId":"s1","timestamp":"2026-10-05T09:00:01Z","message":{"id":"m1","model":"claude-sonnet-4-5","content":[{"type":"tool_use","id":"t1","name":"Bash","input":{}}],"usage":{"input_tokens":10,"output_tokens":20}}}` + "\n"
+	resumeRepeat = `{"type":"assistant","sessionId":"s1","timestamp":"2026-10-05T09:00:02Z","message":{"id":"m1","model":"claude-sonnet-4-5","content":[{"type":"text","text":"ok"}],"usage":{"input_tokens":10,"output_tokens":20}}}` + "\n"
+	resumeResult = `{"type":"user","sessionId":"s1","timestamp":"2026-10-05T09:00:06Z","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"t1"}]}}` + "\n"
+	resumeAnswer = `{"type":"assistant","sessionId":"s1","timestamp":"2026-10-05T09:00:07Z","message":{"id":"m2","model":"claude-sonnet-4-5","usage":{"input_tokens":5,"output_tokens":7}}}` + "\n"
+)
+
+func TestTranscriptIndexResumes(t *testing.T) {
+	dir := t.TempDir()
+	fpath := filepath.Join(dir, "s1.jsonl")
+	cache := filepath.Join(dir, "cache", "transcripts.json")
+
+	appendFile(t, fpath, resumePrompt+resumeTool)
+	first := newTranscriptIndex(cache)
+	if _, errs := first.load([]string{fpath}); len(errs) > 0 {
+		t.Fatalf("first load errors: %v", errs)
+	}
+	if err := first.save(); err != nil {
+		t.Fatal(err)
+	}
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(cache)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if mode := info.Mode().Perm(); mode != 0o600 {
+			t.Errorf("cache file mode = %v, want 0600", mode)
+		}
+	}
+
+	// A new run resumes from the saved state
+	appendFile(t, fpath, resumeRepeat+resumeResult+resumeAnswer)
+	second := newTranscriptIndex(cache)
+	st := second.files[fpath]
+	if st == nil {
+		t.Fatal("saved state not loaded")
+	}
+	if st.Offset != int64(len(resumePrompt+resumeTool)) || st.LastMessageID != "m1" || st.PendingTools["t1"].Name != "Bash" {
+		t.Fatalf("saved state = offset %d, last message %q, pending %v", st.Offset, st.LastMessageID, st.PendingTools)
+	}
+
+	stats, errs := second.load([]string{fpath})
+	if len(errs) > 0 || len(stats) != 1 {
+		t.Fatalf("second load = %d stats, errors %v", len(stats), errs)
+	}
+	s := stats[0]
+	// The repeated line of m1 is neither a message nor usage of its own
+	if s.Usage.Input != 15 || s.Usage.Output != 27 {
+		t.Errorf("usage = %+v, want 15 in and 27 out", s.Usage)
+	}
+	if s.MessageCount != 3 || s.Messages["claude-sonnet-4-5"] != 2 {
+		t.Errorf("messages = %d, %d from the model, want 3 and 2", s.MessageCount, s.Messages["claude-sonnet-4-5"])
+	}
+	date := time.Date(2026, 10, 5, 9, 0, 0, 0, time.UTC).Local().Format("2006-01-02")
+	if got := s.Daily[date]["claude-sonnet-4-5"].Output; got != 27 {
+		t.Errorf("daily output = %d, want 27", got)
+	}
+	// The tool call made before the restart completes after it
+	tool := s.Tools["Bash"]
+	if tool.Calls != 1 || tool.Completed != 1 || tool.Latency != 5*time.Second {
+		t.Errorf("Bash = %+v, want one call completed in 5s", tool)
+	}
+	if st := second.files[fpath]; len(st.PendingTools) != 0 || st.LastMessageID != "m2" {
+		t.Errorf("state = pending %v, last message %q, want none and m2", st.PendingTools, st.LastMessageID)
+	}
+}
+
+func TestTranscriptIndexRereadsReplacedFiles(t *testing.T) {
+	dir := t.TempDir()
+	fpath := filepath.Join(dir, "s1.jsonl")
+	x := newTranscriptIndex("")
+
+	appendFile(t, fpath, resumePrompt+resumeTool)
+	if _, errs := x.load([]string{fpath}); len(errs) > 0 {
+		t.Fatal(errs)
+	}
+
+	// A file replaced by a longer one is read from the start, not from
+	// the old offset
+	replaced := strings.Replace(resumePrompt, `"hi"`, `"hello"`, 1) + resumeAnswer + resumeAnswer
+	if err := os.WriteFile(fpath, []byte(replaced), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	stats, errs := x.load([]string{fpath})
+	if len(errs) > 0 || len(stats) != 1 {
+		t.Fatalf("load = %d stats, errors %v", len(stats), errs)
+	}
+	if s := stats[0]; s.FirstPrompt != "hello" || s.Usage.Output != 7 || s.Tools["Bash"].Calls != 0 {
+		t.Errorf("stats = prompt %q, output %d, %d Bash calls, want hello, 7 and none",
+			s.FirstPrompt, s.Usage.Output, s.Tools["Bash"].Calls)
+	}
+}
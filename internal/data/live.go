@@ -0,0 +1,64 @@
+package data
+
+import (
+	"path/filepath"
+	"sort"
+)
+
+// MarkLiveSessions returns a copy of sessions with Process set on those
+// being run by one of the given Claude processes. A process is matched
+// by the transcript it has open, or else to the most recently modified
+// unclaimed session of the project it is running in.
+func MarkLiveSessions(sessions []SessionEntry, procs []ProcessInfo) []SessionEntry {
+	result := make([]SessionEntry, len(sessions))
+	copy(result, sessions)
+	for i := range result {
+		result[i].Process = nil
+	}
+
+	byTranscript := make(map[string]int, len(result))
+	for i, session := range result {
+		if session.TranscriptPath != "" {
+			byTranscript[session.TranscriptPath] = i
+		}
+	}
+
+	// Open transcripts identify the session exactly
+	var unmatched []int
+	for p := range procs {
+		matched := false
+		for _, fpath := range procs[p].OpenFiles {
+			if i, ok := byTranscript[fpath]; ok && result[i].Process == nil {
+				result[i].Process = &procs[p]
+				matched = true
+				break
+			}
+		}
+		if !matched && procs[p].Cwd != "" {
+			unmatched = append(unmatched, p)
+		}
+	}
+	if len(unmatched) == 0 {
+		return result
+	}
+
+	// Otherwise claim the latest sessions of the working directory
+	recent := make([]int, len(result))
+	for i := range recent {
+		recent[i] = i
+	}
+	sort.SliceStable(recent, func(a, b int) bool {
+		return result[recent[a]].Modified.After(result[recent[b]].Modified)
+	})
+	for _, p := range unmatched {
+		cwd := filepath.Clean(procs[p].Cwd)
+		for _, i := range recent {
+			if result[i].Process == nil && filepath.Clean(result[i].ProjectPath) == cwd {
+				result[i].Process = &procs[p]
+				break
+			}
+		}
+	}
+
+	return result
+}
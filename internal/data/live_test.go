@@ -0,0 +1,56 @@
+package data
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMarkLiveSessions(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	// Two sessions in the same directory, "new" the more recently modified
+	sessions := []SessionEntry{
+		{SessionID: "old", ProjectPath: "/src/app", TranscriptPath: "/c/projects/app/old.jsonl", Modified: now.Add(-time.Hour)},
+		{SessionID: "new", ProjectPath: "/src/app", TranscriptPath: "/c/projects/app/new.jsonl", Modified: now},
+		{SessionID: "other", ProjectPath: "/src/other", TranscriptPath: "/c/projects/other/other.jsonl", Modified: now},
+	}
+	inApp := func(pid int, open ...string) ProcessInfo {
+		return ProcessInfo{PID: pid, Cwd: "/src/app/", OpenFiles: open}
+	}
+
+	tests := []struct {
+		name  string
+		procs []ProcessInfo
+		want  map[string]int // Session ID -> PID of the process marked
+	}{
+		{"none", nil, map[string]int{}},
+		{"latest session of the directory", []ProcessInfo{inApp(1)}, map[string]int{"new": 1}},
+		{"open transcript over the latest", []ProcessInfo{inApp(1, "/c/projects/app/old.jsonl")}, map[string]int{"old": 1}},
+		{
+			"open transcript first, then the latest unclaimed",
+			[]ProcessInfo{inApp(1), inApp(2, "/c/projects/app/new.jsonl")},
+			map[string]int{"new": 2, "old": 1},
+		},
+		{"each process its own session", []ProcessInfo{inApp(1), inApp(2)}, map[string]int{"new": 1, "old": 2}},
+		{"more processes than sessions", []ProcessInfo{inApp(1), inApp(2), inApp(3)}, map[string]int{"new": 1, "old": 2}},
+		{"another directory", []ProcessInfo{{PID: 1, Cwd: "/src/elsewhere"}}, map[string]int{}},
+		{"unknown directory", []ProcessInfo{{PID: 1}}, map[string]int{}},
+	}
+	for _, tt := range tests {
+		result := MarkLiveSessions(sessions, tt.procs)
+		got := make(map[string]int)
+		for _, s := range result {
+			if s.Process != nil {
+				got[s.SessionID] = s.Process.PID
+			}
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: live = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+	for _, s := range sessions {
+		if s.Process != nil {
+			t.Errorf("MarkLiveSessions modified its input: %s is live", s.SessionID)
+		}
+	}
+}
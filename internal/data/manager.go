@@ -1,8 +1,13 @@
 package data
 
 import (
+	"os"
+	"path/filepath"
+	"sort"
 	"sync"
 	"time"
+
+	"github.com/moshe-exe/lazyvibe/internal/config"
 )
 
 // Cache TTL constants
@@ -22,19 +27,136 @@ func (c *cacheEntry[T]) isValid(ttl time.Duration) bool {
 	return time.Since(c.timestamp) < ttl
 }
 
+// fileCache memoizes per-file parse results, reusing them while a file's
+// size and modification time are unchanged.
+type fileCache[T any] struct {
+	mu      sync.Mutex
+	entries map[string]fileCacheEntry[T]
+}
+
+type fileCacheEntry[T any] struct {
+	size    int64
+	modTime time.Time
+	data    T
+	err     error
+	loaded  time.Time
+}
+
+// load returns parse results keyed by path, parsing only new or changed
+// files. Files that fail to parse are returned as errors.
+func (c *fileCache[T]) load(paths []string, parse func(string) (T, error)) (map[string]T, []ParseError) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.entries == nil {
+		c.entries = make(map[string]fileCacheEntry[T])
+	}
+
+	seen := make(map[string]bool, len(paths))
+	results := make(map[string]T, len(paths))
+	var errs []ParseError
+	for _, fpath := range paths {
+		info, err := os.Stat(fpath)
+		if err != nil {
+			continue
+		}
+		seen[fpath] = true
+
+		entry, ok := c.entries[fpath]
+		if !ok || entry.size != info.Size() || !entry.modTime.Equal(info.ModTime()) {
+			parsed, err := parse(fpath)
+			entry = fileCacheEntry[T]{size: info.Size(), modTime: info.ModTime(), data: parsed, err: err, loaded: time.Now()}
+			c.entries[fpath] = entry
+		}
+		if entry.err != nil {
+			errs = append(errs, ParseError{Kind: ErrorUnreadable, Path: fpath, Message: errorText(entry.err), Time: entry.loaded})
+			continue
+		}
+		results[fpath] = entry.data
+	}
+
+	// Forget files that no longer exist
+	for fpath := range c.entries {
+		if !seen[fpath] {
+			delete(c.entries, fpath)
+		}
+	}
+
+	return results, errs
+}
+
 // Manager manages data fetching with caching.
 type Manager struct {
 	mu sync.RWMutex
 
-	vmCache       *cacheEntry[VMStatus]
-	sessionsCache *cacheEntry[[]SessionEntry]
-	statsCache    *cacheEntry[[]DailyActivity]
-	projectsCache *cacheEntry[[]ProjectSummary]
+	roots      []string // Claude data directories
+	pricing    config.PriceTable
+	vmPatterns []string
+	procs      processMonitor
+
+	// commitGrace extends a session past its last activity when linking
+	// commits to it, for commits made just after Claude finished.
+	commitGrace time.Duration
+
+	vmCache          *cacheEntry[VMStatus]
+	processesCache   *cacheEntry[[]ProcessInfo]
+	sessionsCache    *cacheEntry[[]SessionEntry]
+	statsCache       *cacheEntry[[]DailyActivity]
+	projectsCache    *cacheEntry[[]ProjectSummary]
+	transcriptsCache *cacheEntry[map[string]TranscriptStats]
+
+	// Per-file caches so refreshes only re-parse changed files
+	indexFiles  fileCache[sessionsIndex]
+	transcripts *transcriptIndex
+	search      *searchIndex
+	git         gitIndex
+	gitLoaded   chan struct{} // Signalled when commit logs were read
+
+	// Problems found by the latest refresh of each source
+	configErrors     []ParseError
+	indexErrors      []ParseError
+	transcriptErrors []ParseError
+	statsErrors      []ParseError
+	searchErrors     []ParseError
+	indexCount       int
+	transcriptCount  int
+
+	watcher fileWatcher
 }
 
 // NewManager creates a new data manager.
-func NewManager() *Manager {
-	return &Manager{}
+// A nil config uses the defaults.
+func NewManager(cfg *config.Config) *Manager {
+	if cfg == nil {
+		cfg = config.DefaultConfig()
+	}
+	vmPatterns := cfg.VMProcesses
+	if len(vmPatterns) == 0 {
+		vmPatterns = defaultVMPatterns
+	}
+	var roots []string
+	seen := make(map[string]bool)
+	for _, dir := range cfg.ClaudeDirs {
+		root := filepath.Clean(expandPath(dir))
+		if dir != "" && !seen[root] {
+			seen[root] = true
+			roots = append(roots, root)
+		}
+	}
+	if len(roots) == 0 {
+		roots = []string{expandPath(DefaultClaudeDir)}
+	}
+
+	return &Manager{
+		roots:       roots,
+		pricing:     cfg.PriceTable(),
+		vmPatterns:  vmPatterns,
+		procs:       newProcessMonitor(),
+		commitGrace: time.Duration(max(cfg.CommitGraceMinutes, 0)) * time.Minute,
+		transcripts: newTranscriptIndex(cachePath(ingestCacheName)),
+		search:      newSearchIndex(cachePath(searchIndexName)),
+		gitLoaded:   make(chan struct{}, 1),
+	}
 }
 
 // GetVMStatus returns the VM status with caching.
@@ -47,7 +169,7 @@ func (m *Manager) GetVMStatus(forceRefresh bool) VMStatus {
 	}
 	m.mu.RUnlock()
 
-	status := GetVMStatus()
+	status := m.procs.VMStatus(m.vmPatterns)
 
 	m.mu.Lock()
 	m.vmCache = &cacheEntry[VMStatus]{data: status, timestamp: time.Now()}
@@ -56,6 +178,25 @@ func (m *Manager) GetVMStatus(forceRefresh bool) VMStatus {
 	return status
 }
 
+// GetProcesses returns running Claude Code CLI processes with caching.
+func (m *Manager) GetProcesses(forceRefresh bool) []ProcessInfo {
+	m.mu.RLock()
+	if !forceRefresh && m.processesCache != nil && m.processesCache.isValid(VMTTL) {
+		procs := m.processesCache.data
+		m.mu.RUnlock()
+		return procs
+	}
+	m.mu.RUnlock()
+
+	procs := m.procs.ClaudeProcesses()
+
+	m.mu.Lock()
+	m.processesCache = &cacheEntry[[]ProcessInfo]{data: procs, timestamp: time.Now()}
+	m.mu.Unlock()
+
+	return procs
+}
+
 // GetSessions returns sessions with caching.
 func (m *Manager) GetSessions(forceRefresh bool) []SessionEntry {
 	m.mu.RLock()
@@ -66,16 +207,60 @@ func (m *Manager) GetSessions(forceRefresh bool) []SessionEntry {
 	}
 	m.mu.RUnlock()
 
-	sessions := ParseSessions()
+	paths := globRoots(m.roots, sessionsIndexGlob)
+	indexes, errs := m.indexFiles.load(paths, parseSessionsIndex)
+	var sessions []SessionEntry
+	indexed := make(map[string]bool)
+	for _, fpath := range paths {
+		for _, s := range indexes[fpath].Sessions {
+			// A session indexed under several roots is listed once
+			if !indexed[s.SessionID] {
+				indexed[s.SessionID] = true
+				sessions = append(sessions, s)
+			}
+		}
+		errs = append(errs, indexes[fpath].Errors...)
+	}
+	transcripts := m.GetTranscripts(forceRefresh)
+	sessions = AddTranscriptSessions(sessions, transcripts)
+	AttachSessionUsage(sessions, transcripts, m.pricing)
+	if stale := m.git.attach(sessions, m.commitGrace); len(stale) > 0 {
+		m.git.loadAsync(stale, m.commitsLoaded)
+	}
 
 	m.mu.Lock()
 	m.sessionsCache = &cacheEntry[[]SessionEntry]{data: sessions, timestamp: time.Now()}
+	m.indexErrors = errs
+	m.indexCount = len(paths)
 	m.projectsCache = nil // Invalidate projects cache when sessions change
 	m.mu.Unlock()
 
 	return sessions
 }
 
+// commitsLoaded drops the cached sessions once commit logs were read in
+// the background, so the next read links sessions to their commits.
+func (m *Manager) commitsLoaded() {
+	m.mu.Lock()
+	m.sessionsCache = nil
+	m.projectsCache = nil
+	m.mu.Unlock()
+
+	select {
+	case m.gitLoaded <- struct{}{}:
+	default:
+	}
+}
+
+// LoadCommits reads the commit logs of the sessions' repositories and
+// waits for them, where other reads link commits in the background. It is
+// for one-off reads, such as the command line's, that need commits from
+// the start.
+func (m *Manager) LoadCommits() {
+	m.GetSessions(false)
+	m.git.wait()
+}
+
 // GetDailyActivity returns daily activity with caching.
 func (m *Manager) GetDailyActivity(forceRefresh bool) []DailyActivity {
 	m.mu.RLock()
@@ -86,10 +271,13 @@ func (m *Manager) GetDailyActivity(forceRefresh bool) []DailyActivity {
 	}
 	m.mu.RUnlock()
 
-	activity := ParseStatsCache()
+	// Transcripts were already refreshed along with sessions
+	daily, errs := ParseStatsCache(m.roots)
+	activity := MergeDailyUsage(daily, m.GetTranscripts(false), m.pricing)
 
 	m.mu.Lock()
 	m.statsCache = &cacheEntry[[]DailyActivity]{data: activity, timestamp: time.Now()}
+	m.statsErrors = errs
 	m.mu.Unlock()
 
 	return activity
@@ -105,7 +293,8 @@ func (m *Manager) GetProjects(forceRefresh bool) []ProjectSummary {
 	}
 	m.mu.RUnlock()
 
-	sessions := m.GetSessions(forceRefresh)
+	// A sessions refresh invalidates this cache, so cached sessions are current
+	sessions := m.GetSessions(false)
 	projects := AggregateProjects(sessions)
 
 	m.mu.Lock()
@@ -115,14 +304,116 @@ func (m *Manager) GetProjects(forceRefresh bool) []ProjectSummary {
 	return projects
 }
 
+// GetTranscripts returns parsed transcript stats with caching.
+func (m *Manager) GetTranscripts(forceRefresh bool) map[string]TranscriptStats {
+	m.mu.RLock()
+	if !forceRefresh && m.transcriptsCache != nil && m.transcriptsCache.isValid(SessionsTTL) {
+		transcripts := m.transcriptsCache.data
+		m.mu.RUnlock()
+		return transcripts
+	}
+	m.mu.RUnlock()
+
+	paths := globRoots(m.roots, transcriptsGlob)
+	parsed, errs := m.transcripts.load(paths)
+	for _, stats := range parsed {
+		errs = append(errs, stats.Errors...)
+	}
+	transcripts := mergeTranscripts(parsed)
+
+	m.mu.Lock()
+	m.transcriptsCache = &cacheEntry[map[string]TranscriptStats]{data: transcripts, timestamp: time.Now()}
+	m.transcriptErrors = errs
+	m.transcriptCount = len(paths)
+	m.mu.Unlock()
+
+	return transcripts
+}
+
+// Search returns up to limit sessions whose prompts or responses contain
+// every word of query, most recent first. A limit of 0 returns all. The
+// search index is brought up to date with the transcripts first.
+func (m *Manager) Search(query string, limit int) []SearchResult {
+	results, errs := m.search.search(globRoots(m.roots, transcriptsGlob), query, limit)
+	m.mu.Lock()
+	m.searchErrors = errs
+	m.mu.Unlock()
+
+	// Describe sessions as the Sessions panel does
+	sessions := make(map[string]SessionEntry)
+	for _, s := range m.GetSessions(false) {
+		sessions[s.SessionID] = s
+	}
+	for i := range results {
+		if s, ok := sessions[results[i].SessionID]; ok {
+			results[i].ProjectName = s.ProjectName
+			results[i].ProjectPath = s.ProjectPath
+			results[i].Summary = s.Summary
+		}
+	}
+	return results
+}
+
 // GetDashboardData returns all dashboard data.
 func (m *Manager) GetDashboardData(forceRefresh bool) DashboardData {
+	procs := m.GetProcesses(forceRefresh)
 	return DashboardData{
 		VMStatus:      m.GetVMStatus(forceRefresh),
-		Sessions:      m.GetSessions(forceRefresh),
+		Processes:     procs,
+		Sessions:      MarkLiveSessions(m.GetSessions(forceRefresh), procs),
 		DailyActivity: m.GetDailyActivity(forceRefresh),
 		Projects:      m.GetProjects(forceRefresh),
+		Diagnostics:   m.Diagnostics(),
+	}
+}
+
+// AddConfigError records a problem loading the configuration, which is
+// reported along with the data problems.
+func (m *Manager) AddConfigError(path string, err error) {
+	m.mu.Lock()
+	m.configErrors = append(m.configErrors, newParseError(ErrorConfig, path, 0, err.Error()))
+	m.mu.Unlock()
+}
+
+// ParseErrors returns the problems found during the latest refresh,
+// ordered by path and line.
+func (m *Manager) ParseErrors() []ParseError {
+	m.mu.RLock()
+	var errs []ParseError
+	for _, source := range [][]ParseError{m.configErrors, m.indexErrors, m.transcriptErrors, m.statsErrors, m.searchErrors} {
+		errs = append(errs, source...)
+	}
+	m.mu.RUnlock()
+
+	sort.SliceStable(errs, func(i, j int) bool {
+		if errs[i].Path != errs[j].Path {
+			return errs[i].Path < errs[j].Path
+		}
+		return errs[i].Line < errs[j].Line
+	})
+	return errs
+}
+
+// Roots returns the Claude data directories being read.
+func (m *Manager) Roots() []string {
+	return m.roots
+}
+
+// Close stops watching, if started, and saves the ingestion cache and the
+// search index.
+func (m *Manager) Close() error {
+	m.mu.Lock()
+	if m.watcher != nil {
+		m.watcher.Close()
+		m.watcher = nil
+	}
+	m.mu.Unlock()
+
+	err := m.transcripts.save()
+	if searchErr := m.search.save(); err == nil {
+		err = searchErr
 	}
+	return err
 }
 
 // RefreshAll forces a refresh of all data.
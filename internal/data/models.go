@@ -3,19 +3,12 @@ package data
 
 import (
 	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 )
 
-// TimeRange represents a time filter for data.
-type TimeRange int
-
-const (
-	TimeAll TimeRange = iota
-	TimeToday
-	TimeWeek
-	TimeMonth
-)
-
 // VMStatus represents the status of the Claude Desktop VM.
 type VMStatus struct {
 	Running    bool
@@ -24,6 +17,16 @@ type VMStatus struct {
 	MemoryMB   *float64
 }
 
+// ProcessInfo represents a running process and its resource usage.
+type ProcessInfo struct {
+	PID        int
+	Command    string
+	CPUPercent float64
+	MemoryMB   float64
+	Cwd        string   // Working directory, if known
+	OpenFiles  []string // Open transcript files, if known
+}
+
 // SessionEntry represents a Claude Code session entry.
 type SessionEntry struct {
 	SessionID    string
@@ -31,19 +34,170 @@ type SessionEntry struct {
 	ProjectName  string
 	Summary      string
 	MessageCount int
-	Created      time.Time
-	Modified     time.Time
+	Created      time.Time // Zero if unknown
+	Modified     time.Time // Zero if unknown
 	GitBranch    *string
+
+	// Source is the Claude data directory the session was read from.
+	Source string
+	// TranscriptPath is the session's JSONL transcript file.
+	TranscriptPath string
+	// Tokens is the measured token usage from the session transcript.
+	Tokens TokenUsage
+	// Cost is the estimated USD cost of Tokens.
+	Cost float64
+	// Models breaks usage down by the model that answered.
+	Models map[string]ModelStats
+	// Tools holds per-tool call statistics keyed by tool name.
+	Tools map[string]ToolStats
+	// Files counts the edits and reads of each file, keyed by path.
+	Files map[string]FileStats
+	// Git holds the commits made during the session, nil if the project
+	// is not a readable git repository.
+	Git *GitActivity
+	// Process is the Claude process running the session, nil if not live.
+	Process *ProcessInfo
+}
+
+// SourceName returns a short label for the session's data directory,
+// e.g. "alice" for /shared/alice/.claude or "claude-work" for ~/.claude-work.
+func (s *SessionEntry) SourceName() string {
+	name := filepath.Base(s.Source)
+	if name == ".claude" {
+		name = filepath.Base(filepath.Dir(s.Source))
+	}
+	return strings.TrimPrefix(name, ".")
+}
+
+// IsLive returns whether a Claude process is running the session.
+func (s *SessionEntry) IsLive() bool {
+	return s.Process != nil
+}
+
+// ToolStats holds call statistics for a single tool.
+type ToolStats struct {
+	Calls     int
+	Errors    int
+	Completed int           // Calls with a matching result
+	Latency   time.Duration // Summed over completed calls
+}
+
+// add accumulates another tool's stats into t.
+func (t *ToolStats) add(other ToolStats) {
+	t.Calls += other.Calls
+	t.Errors += other.Errors
+	t.Completed += other.Completed
+	t.Latency += other.Latency
 }
 
-// Duration returns the session duration based on created and modified times.
+// ErrorRate returns the fraction of completed calls that failed.
+func (t ToolStats) ErrorRate() float64 {
+	if t.Completed == 0 {
+		return 0
+	}
+	return float64(t.Errors) / float64(t.Completed)
+}
+
+// AvgLatency returns the mean time between a call and its result.
+func (t ToolStats) AvgLatency() time.Duration {
+	if t.Completed == 0 {
+		return 0
+	}
+	return t.Latency / time.Duration(t.Completed)
+}
+
+// ToolUsage holds statistics for a single tool aggregated across sessions.
+type ToolUsage struct {
+	Name     string
+	Sessions int
+	ToolStats
+}
+
+// FileStats counts the tool calls on a file.
+type FileStats struct {
+	Edits int // Edit, Write, MultiEdit and NotebookEdit calls
+	Reads int // Read calls
+}
+
+// add accumulates another file's counts into f.
+func (f *FileStats) add(other FileStats) {
+	f.Edits += other.Edits
+	f.Reads += other.Reads
+}
+
+// FileUsage holds a file's tool calls aggregated across sessions.
+type FileUsage struct {
+	Path        string
+	ProjectName string
+	ProjectPath string
+	RelPath     string    // Path within the project, or Path if outside it
+	Sessions    []string  // IDs of the sessions that touched the file, most recent first
+	LastTouched time.Time // Last activity of the most recent of those sessions
+	FileStats
+}
+
+// DirectoryUsage holds the tool calls on the files of a directory,
+// excluding its subdirectories, aggregated across sessions.
+type DirectoryUsage struct {
+	Path        string
+	ProjectName string
+	ProjectPath string
+	RelPath     string // Path within the project, or Path if outside it
+	Files       int    // Files touched
+	Sessions    int
+	LastTouched time.Time
+	FileStats
+}
+
+// ModelStats holds a session's usage for a single model.
+type ModelStats struct {
+	Messages int
+	Tokens   TokenUsage
+	Cost     float64
+}
+
+// ModelUsage holds usage for a single model aggregated across sessions.
+type ModelUsage struct {
+	Model    string
+	Messages int
+	Sessions int
+	Tokens   TokenUsage
+	Cost     float64
+}
+
+// TimesKnown returns whether both the created and modified times are known.
+func (s SessionEntry) TimesKnown() bool {
+	return !s.Created.IsZero() && !s.Modified.IsZero()
+}
+
+// StartTime returns when the session started, falling back to its last
+// activity if the start is unknown.
+func (s SessionEntry) StartTime() time.Time {
+	if !s.Created.IsZero() {
+		return s.Created
+	}
+	return s.Modified
+}
+
+// Duration returns the session duration based on created and modified times,
+// or 0 if either is unknown.
 func (s SessionEntry) Duration() time.Duration {
+	if !s.TimesKnown() {
+		return 0
+	}
 	return s.Modified.Sub(s.Created)
 }
 
-// FormatDuration returns a human-readable duration string.
+// FormatDuration returns a human-readable duration string, "—" if unknown.
 func (s SessionEntry) FormatDuration() string {
-	d := s.Duration()
+	if !s.TimesKnown() {
+		return "—"
+	}
+	return FormatDuration(s.Duration())
+}
+
+// FormatDuration returns a duration as e.g. "<1m", "45m" or "2h10m".
+func FormatDuration(d time.Duration) string {
 	if d < time.Minute {
 		return "<1m"
 	}
@@ -64,7 +218,30 @@ type DailyActivity struct {
 	MessageCount  int
 	SessionCount  int
 	ToolCallCount int
-	TokenCount    int // Estimated tokens
+	TokenCount    int        // Measured tokens from transcripts
+	Tokens        TokenUsage // Breakdown of TokenCount
+	Cost          float64    // Estimated USD cost
+}
+
+// TokenUsage holds token counts reported in transcript usage blocks.
+type TokenUsage struct {
+	Input         int
+	Output        int
+	CacheCreation int
+	CacheRead     int
+}
+
+// Total returns the sum of all token counts.
+func (u TokenUsage) Total() int {
+	return u.Input + u.Output + u.CacheCreation + u.CacheRead
+}
+
+// Add accumulates another usage into u.
+func (u *TokenUsage) Add(other TokenUsage) {
+	u.Input += other.Input
+	u.Output += other.Output
+	u.CacheCreation += other.CacheCreation
+	u.CacheRead += other.CacheRead
 }
 
 // ProjectSummary represents aggregated stats for a project.
@@ -73,15 +250,87 @@ type ProjectSummary struct {
 	ProjectPath   string
 	SessionCount  int
 	TotalMessages int
+	TotalCost     float64
 	LastActivity  time.Time
+	Branches      []string // Git branches of the project's sessions, sorted
+	Models        []string // Models used in the project's sessions, sorted
+
+	FirstActivity  time.Time
+	TotalDuration  time.Duration // Summed over sessions with known times
+	TimedSessions  int           // Sessions whose duration is known
+	Tokens         TokenUsage
+	Tools          map[string]ToolUsage // Per-tool statistics keyed by tool name
+	BranchSessions map[string]int       // Sessions per git branch
+	// Daily is the project's activity by day, oldest first. Sessions count
+	// on the day they started.
+	Daily []DailyActivity
+
+	Files map[string]FileStats // Edits and reads per file across sessions
+
+	HasGit       bool // Whether a session's project is a readable git repository
+	Commits      int  // Distinct commits made during the project's sessions
+	LinesAdded   int
+	LinesRemoved int
+}
+
+// AverageDuration returns the mean length of sessions with known times.
+func (p ProjectSummary) AverageDuration() time.Duration {
+	if p.TimedSessions == 0 {
+		return 0
+	}
+	return p.TotalDuration / time.Duration(p.TimedSessions)
+}
+
+// BranchCount is a git branch and the number of sessions on it.
+type BranchCount struct {
+	Branch   string
+	Sessions int
+}
+
+// TopBranches returns up to n branches with the most sessions.
+func (p ProjectSummary) TopBranches(n int) []BranchCount {
+	result := make([]BranchCount, 0, len(p.BranchSessions))
+	for branch, count := range p.BranchSessions {
+		result = append(result, BranchCount{Branch: branch, Sessions: count})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Sessions != result[j].Sessions {
+			return result[i].Sessions > result[j].Sessions
+		}
+		return result[i].Branch < result[j].Branch
+	})
+	if len(result) > n {
+		result = result[:n]
+	}
+	return result
+}
+
+// TopTools returns up to n tools with the most calls.
+func (p ProjectSummary) TopTools(n int) []ToolUsage {
+	result := make([]ToolUsage, 0, len(p.Tools))
+	for _, usage := range p.Tools {
+		result = append(result, usage)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Calls != result[j].Calls {
+			return result[i].Calls > result[j].Calls
+		}
+		return result[i].Name < result[j].Name
+	})
+	if len(result) > n {
+		result = result[:n]
+	}
+	return result
 }
 
 // DashboardData aggregates all dashboard data.
 type DashboardData struct {
 	VMStatus      VMStatus
+	Processes     []ProcessInfo // Running Claude Code CLI processes
 	Sessions      []SessionEntry
 	DailyActivity []DailyActivity
 	Projects      []ProjectSummary
+	Diagnostics   Diagnostics
 }
 
 // TotalSessions returns the total number of sessions.
@@ -145,7 +394,7 @@ func (d *DashboardData) GetToolCallTrend(n int) []int {
 	return values
 }
 
-// TotalTokens returns the total estimated tokens from daily activity.
+// TotalTokens returns the total measured tokens from daily activity.
 func (d *DashboardData) TotalTokens() int {
 	total := 0
 	for _, a := range d.DailyActivity {
@@ -154,6 +403,15 @@ func (d *DashboardData) TotalTokens() int {
 	return total
 }
 
+// TotalCost returns the total estimated cost from daily activity.
+func (d *DashboardData) TotalCost() float64 {
+	total := 0.0
+	for _, a := range d.DailyActivity {
+		total += a.Cost
+	}
+	return total
+}
+
 // GetTokenTrend returns token counts for the last n days.
 func (d *DashboardData) GetTokenTrend(n int) []int {
 	days := d.GetLastNDays(n)
@@ -164,77 +422,193 @@ func (d *DashboardData) GetTokenTrend(n int) []int {
 	return values
 }
 
-// TimeRangeName returns a display name for the time range.
-func (tr TimeRange) String() string {
-	switch tr {
-	case TimeToday:
-		return "Today"
-	case TimeWeek:
-		return "This Week"
-	case TimeMonth:
-		return "This Month"
-	default:
-		return "All Time"
+// ModelBreakdown aggregates per-model usage across sessions in the time range,
+// ranked by total tokens. Like the session list, it counts whole sessions by
+// their last activity, so its totals can differ from DailyActivity's, which
+// count each day's usage on that day.
+func (d *DashboardData) ModelBreakdown(tr TimeRange) []ModelUsage {
+	models := make(map[string]*ModelUsage)
+	for _, s := range d.FilterSessions(tr) {
+		for name, stats := range s.Models {
+			usage, ok := models[name]
+			if !ok {
+				usage = &ModelUsage{Model: name}
+				models[name] = usage
+			}
+			usage.Messages += stats.Messages
+			usage.Sessions++
+			usage.Tokens.Add(stats.Tokens)
+			usage.Cost += stats.Cost
+		}
+	}
+
+	result := make([]ModelUsage, 0, len(models))
+	for _, usage := range models {
+		result = append(result, *usage)
 	}
+
+	sort.Slice(result, func(i, j int) bool {
+		ti, tj := result[i].Tokens.Total(), result[j].Tokens.Total()
+		if ti != tj {
+			return ti > tj
+		}
+		return result[i].Model < result[j].Model
+	})
+
+	return result
 }
 
-// StartTime returns the start time for filtering based on the time range.
-func (tr TimeRange) StartTime() time.Time {
-	now := time.Now()
-	switch tr {
-	case TimeToday:
-		return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
-	case TimeWeek:
-		return now.AddDate(0, 0, -7)
-	case TimeMonth:
-		return now.AddDate(0, -1, 0)
-	default:
-		return time.Time{} // Zero time means no filter
+// ToolBreakdown aggregates per-tool statistics across sessions in the time range,
+// ranked by call count. Whole sessions count by their last activity, as in
+// ModelBreakdown.
+func (d *DashboardData) ToolBreakdown(tr TimeRange) []ToolUsage {
+	tools := make(map[string]*ToolUsage)
+	for _, s := range d.FilterSessions(tr) {
+		for name, stats := range s.Tools {
+			usage, ok := tools[name]
+			if !ok {
+				usage = &ToolUsage{Name: name}
+				tools[name] = usage
+			}
+			usage.Sessions++
+			usage.add(stats)
+		}
 	}
-}
 
-// FilterSessions filters sessions by time range.
-func (d *DashboardData) FilterSessions(tr TimeRange) []SessionEntry {
-	if tr == TimeAll {
-		return d.Sessions
+	result := make([]ToolUsage, 0, len(tools))
+	for _, usage := range tools {
+		result = append(result, *usage)
 	}
-	startTime := tr.StartTime()
-	var filtered []SessionEntry
-	for _, s := range d.Sessions {
-		if s.Modified.After(startTime) {
-			filtered = append(filtered, s)
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Calls != result[j].Calls {
+			return result[i].Calls > result[j].Calls
 		}
-	}
-	return filtered
+		return result[i].Name < result[j].Name
+	})
+
+	return result
 }
 
-// FilterProjects filters projects by time range.
-func (d *DashboardData) FilterProjects(tr TimeRange) []ProjectSummary {
-	if tr == TimeAll {
-		return d.Projects
-	}
-	startTime := tr.StartTime()
-	var filtered []ProjectSummary
-	for _, p := range d.Projects {
-		if p.LastActivity.After(startTime) {
-			filtered = append(filtered, p)
+// FileBreakdown aggregates per-file edits and reads across sessions in the
+// time range, ranked by edits then reads.
+func (d *DashboardData) FileBreakdown(tr TimeRange) []FileUsage {
+	sessions := append([]SessionEntry(nil), d.FilterSessions(tr)...)
+	// Most recent first, so each file lists its sessions in that order
+	sort.SliceStable(sessions, func(i, j int) bool {
+		return sessions[i].Modified.After(sessions[j].Modified)
+	})
+
+	files := make(map[string]*FileUsage)
+	for _, s := range sessions {
+		for path, stats := range s.Files {
+			usage, ok := files[path]
+			if !ok {
+				usage = &FileUsage{
+					Path:        path,
+					ProjectName: s.ProjectName,
+					ProjectPath: s.ProjectPath,
+					RelPath:     RelativePath(s.ProjectPath, path),
+					LastTouched: s.Modified,
+				}
+				files[path] = usage
+			}
+			usage.Sessions = append(usage.Sessions, s.SessionID)
+			usage.add(stats)
 		}
 	}
-	return filtered
+
+	result := make([]FileUsage, 0, len(files))
+	for _, usage := range files {
+		result = append(result, *usage)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Edits != result[j].Edits {
+			return result[i].Edits > result[j].Edits
+		}
+		if result[i].Reads != result[j].Reads {
+			return result[i].Reads > result[j].Reads
+		}
+		return result[i].Path < result[j].Path
+	})
+
+	return result
 }
 
-// FilterDailyActivity filters daily activity by time range.
-func (d *DashboardData) FilterDailyActivity(tr TimeRange) []DailyActivity {
-	if tr == TimeAll {
-		return d.DailyActivity
+// DirectoryBreakdown aggregates files, as returned by FileBreakdown, by the
+// directory that directly contains them, ranked by edits then reads.
+func DirectoryBreakdown(files []FileUsage) []DirectoryUsage {
+	dirs := make(map[string]*DirectoryUsage)
+	sessions := make(map[string]map[string]bool)
+	for _, file := range files {
+		path := filepath.Dir(file.Path)
+		usage, ok := dirs[path]
+		if !ok {
+			usage = &DirectoryUsage{
+				Path:        path,
+				ProjectName: file.ProjectName,
+				ProjectPath: file.ProjectPath,
+				RelPath:     RelativePath(file.ProjectPath, path),
+			}
+			dirs[path] = usage
+			sessions[path] = make(map[string]bool)
+		}
+		usage.Files++
+		usage.add(file.FileStats)
+		if file.LastTouched.After(usage.LastTouched) {
+			usage.LastTouched = file.LastTouched
+		}
+		for _, id := range file.Sessions {
+			sessions[path][id] = true
+		}
 	}
-	startTime := tr.StartTime()
-	startDate := startTime.Format("2006-01-02")
-	var filtered []DailyActivity
-	for _, day := range d.DailyActivity {
-		if day.Date >= startDate {
-			filtered = append(filtered, day)
+
+	result := make([]DirectoryUsage, 0, len(dirs))
+	for path, usage := range dirs {
+		usage.Sessions = len(sessions[path])
+		result = append(result, *usage)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Edits != result[j].Edits {
+			return result[i].Edits > result[j].Edits
+		}
+		if result[i].Reads != result[j].Reads {
+			return result[i].Reads > result[j].Reads
 		}
+		return result[i].Path < result[j].Path
+	})
+
+	return result
+}
+
+// RelativePath returns path relative to the project directory, or path
+// itself if it lies outside it.
+func RelativePath(project, path string) string {
+	if project == "" {
+		return path
+	}
+	rel, err := filepath.Rel(project, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return path
 	}
-	return filtered
+	return rel
+}
+
+// FilterSessions filters sessions by time range. Sessions whose modified
+// time is unknown only appear in TimeAll.
+func (d *DashboardData) FilterSessions(tr TimeRange) []SessionEntry {
+	return d.SessionsIn(tr.Period())
+}
+
+// FilterProjects filters projects by time range. Projects whose last
+// activity is unknown only appear in TimeAll.
+func (d *DashboardData) FilterProjects(tr TimeRange) []ProjectSummary {
+	return d.ProjectsIn(tr.Period())
+}
+
+// FilterDailyActivity filters daily activity by time range.
+func (d *DashboardData) FilterDailyActivity(tr TimeRange) []DailyActivity {
+	return d.DailyActivityIn(tr.Period())
 }
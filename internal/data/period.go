@@ -0,0 +1,113 @@
+package data
+
+import "time"
+
+// Period is the span of time from Start up to but excluding End. A zero
+// Start or End leaves that side open; a period open on both sides is all
+// time.
+type Period struct {
+	Start time.Time
+	End   time.Time
+}
+
+// IsAll returns whether the period covers all time.
+func (p Period) IsAll() bool {
+	return p.Start.IsZero() && p.End.IsZero()
+}
+
+// Contains returns whether t lies in the period. An unknown (zero) time
+// only lies in all time.
+func (p Period) Contains(t time.Time) bool {
+	if p.IsAll() {
+		return true
+	}
+	if t.IsZero() {
+		return false
+	}
+	return !t.Before(p.Start) && (p.End.IsZero() || t.Before(p.End))
+}
+
+// containsDate returns whether any of a local date (2006-01-02) lies in
+// the period.
+func (p Period) containsDate(date string) bool {
+	if !p.Start.IsZero() && date < p.Start.Format("2006-01-02") {
+		return false
+	}
+	return p.End.IsZero() || date <= p.lastDay()
+}
+
+// lastDay returns the date of the last instant before End.
+func (p Period) lastDay() string {
+	return p.End.Add(-time.Nanosecond).Format("2006-01-02")
+}
+
+// String describes the period, e.g. "2026-10-01..2026-10-07", or
+// "2026-10-01" for a single day.
+func (p Period) String() string {
+	if p.IsAll() {
+		return "All Time"
+	}
+	if !p.Start.IsZero() && !p.End.IsZero() && p.Start.Format("2006-01-02") == p.lastDay() {
+		return p.lastDay()
+	}
+	var from, to string
+	if !p.Start.IsZero() {
+		from = p.Start.Format("2006-01-02")
+	}
+	if !p.End.IsZero() {
+		to = p.lastDay()
+	}
+	return from + ".." + to
+}
+
+// ParsePeriod parses a time range as ParseTimeRange does and returns the
+// span of time it covers now.
+func ParsePeriod(s string) (Period, error) {
+	tr, err := ParseTimeRange(s)
+	if err != nil {
+		return Period{}, err
+	}
+	return tr.Period(), nil
+}
+
+// SessionsIn returns the sessions last active in the period.
+func (d *DashboardData) SessionsIn(p Period) []SessionEntry {
+	if p.IsAll() {
+		return d.Sessions
+	}
+	var filtered []SessionEntry
+	for _, s := range d.Sessions {
+		if p.Contains(s.Modified) {
+			filtered = append(filtered, s)
+		}
+	}
+	return filtered
+}
+
+// ProjectsIn returns the projects last active in the period.
+func (d *DashboardData) ProjectsIn(p Period) []ProjectSummary {
+	if p.IsAll() {
+		return d.Projects
+	}
+	var filtered []ProjectSummary
+	for _, project := range d.Projects {
+		if p.Contains(project.LastActivity) {
+			filtered = append(filtered, project)
+		}
+	}
+	return filtered
+}
+
+// DailyActivityIn returns the daily activity of the days in the period.
+func (d *DashboardData) DailyActivityIn(p Period) []DailyActivity {
+	if p.IsAll() {
+		return d.DailyActivity
+	}
+	var filtered []DailyActivity
+	for _, day := range d.DailyActivity {
+		if p.containsDate(day.Date) {
+			filtered = append(filtered, day)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,573 @@
+package data
+
+import (
+	"bufio"
+	"encoding/gob"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// searchIndexName is the search index's file name in the cache directory.
+const searchIndexName = "search.gob"
+
+// searchIndexVersion is bumped whenever the index format changes,
+// discarding indexes written by older versions.
+const searchIndexVersion = 1
+
+// Search tokens shorter or longer than these are not indexed.
+const (
+	minSearchToken = 2
+	maxSearchToken = 40
+)
+
+// searchSnippetLen is the length of result snippets in runes.
+const searchSnippetLen = 160
+
+// SearchResult is a session matching a full-text search, represented by
+// its most recent matching message.
+type SearchResult struct {
+	SessionID   string
+	ProjectName string
+	ProjectPath string
+	Summary     string
+	Path        string    // Transcript containing the message
+	Line        int       // 1-based line of the message
+	Role        string    // "user" or "assistant"
+	Timestamp   time.Time // Zero if unknown
+	Snippet     string
+	Matches     [][2]int // Byte ranges of matched terms in Snippet
+	Hits        int      // Matching messages in the session
+}
+
+// searchMessage locates an indexed prompt or response in its transcript.
+type searchMessage struct {
+	Offset int64 // Byte offset of the line
+	Line   int
+	Time   time.Time
+}
+
+// searchDoc is the index of one transcript file.
+type searchDoc struct {
+	Size      int64
+	ModTime   time.Time
+	Offset    int64  // Bytes indexed so far
+	Head      uint64 // Hash of the file's first bytes indexed
+	Lines     int
+	SessionID string
+	Cwd       string
+	Messages  []searchMessage
+	Terms     map[string][]int32 // Token -> ascending message indices
+}
+
+// searchIndexFile is the on-disk format of the search index. It is
+// stored as gob, being too large to rewrite as JSON on every change.
+type searchIndexFile struct {
+	Version int
+	Docs    map[string]*searchDoc
+}
+
+// searchPosting identifies a message in the index.
+type searchPosting struct {
+	doc string
+	msg int32
+}
+
+// searchIndex is an inverted index of prompt and response text. Like
+// the ingestion cache, it only reads what was appended to transcripts
+// since the last update and persists its progress.
+type searchIndex struct {
+	mu     sync.Mutex
+	path   string // Index file, empty to keep the index in memory only
+	loaded bool
+	docs   map[string]*searchDoc
+	dirty  bool
+	saved  time.Time
+
+	// Vocabulary over all documents, rebuilt after documents change
+	terms    []string // Sorted
+	postings map[string][]searchPosting
+}
+
+// newSearchIndex creates an index backed by the file at path. The file
+// is read on first use, since most runs never search.
+func newSearchIndex(path string) *searchIndex {
+	return &searchIndex{path: path, docs: make(map[string]*searchDoc)}
+}
+
+// loadLocked reads the index file. A missing, unreadable or outdated
+// index starts empty. The caller holds x.mu.
+func (x *searchIndex) loadLocked() {
+	x.loaded = true
+	if x.path == "" {
+		return
+	}
+	f, err := os.Open(x.path)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	var file searchIndexFile
+	if err := gob.NewDecoder(f).Decode(&file); err != nil || file.Version != searchIndexVersion {
+		return
+	}
+	for fpath, doc := range file.Docs {
+		if doc != nil {
+			x.docs[fpath] = doc
+		}
+	}
+}
+
+// update indexes new or changed transcripts and forgets removed ones.
+// Files that cannot be indexed, and an index that cannot be saved, are
+// returned as errors. The caller holds x.mu.
+func (x *searchIndex) update(paths []string) []ParseError {
+	if !x.loaded {
+		x.loadLocked()
+	}
+
+	type job struct {
+		path string
+		info os.FileInfo
+		doc  *searchDoc
+	}
+
+	seen := make(map[string]bool, len(paths))
+	var jobs []job
+	for _, fpath := range paths {
+		info, err := os.Stat(fpath)
+		if err != nil {
+			continue
+		}
+		seen[fpath] = true
+
+		doc := x.docs[fpath]
+		if doc != nil && doc.Size == info.Size() && doc.ModTime.Equal(info.ModTime()) {
+			continue
+		}
+		jobs = append(jobs, job{path: fpath, info: info, doc: doc})
+	}
+
+	results := make([]*searchDoc, len(jobs))
+	errs := make([]error, len(jobs))
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, runtime.GOMAXPROCS(0))
+	for i, j := range jobs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, j job) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i], errs[i] = indexTranscript(j.path, j.info, j.doc)
+		}(i, j)
+	}
+	wg.Wait()
+
+	var failed []ParseError
+	changed := len(jobs) > 0
+	for i, j := range jobs {
+		if errs[i] != nil {
+			delete(x.docs, j.path)
+			failed = append(failed, newParseError(ErrorUnreadable, j.path, 0, errorText(errs[i])))
+		} else {
+			x.docs[j.path] = results[i]
+		}
+	}
+	for fpath := range x.docs {
+		if !seen[fpath] {
+			delete(x.docs, fpath)
+			changed = true
+		}
+	}
+
+	if changed || x.postings == nil {
+		x.buildVocabulary()
+	}
+	x.dirty = x.dirty || changed
+	if x.dirty && time.Since(x.saved) >= ingestSaveInterval {
+		if err := x.saveLocked(); err != nil {
+			failed = append(failed, newParseError(ErrorCache, x.path, 0, errorText(err)))
+		}
+	}
+	return failed
+}
+
+// buildVocabulary merges the per-document terms into the global
+// inverted index.
+func (x *searchIndex) buildVocabulary() {
+	x.postings = make(map[string][]searchPosting)
+	for fpath, doc := range x.docs {
+		for term, msgs := range doc.Terms {
+			for _, msg := range msgs {
+				x.postings[term] = append(x.postings[term], searchPosting{doc: fpath, msg: msg})
+			}
+		}
+	}
+	x.terms = make([]string, 0, len(x.postings))
+	for term := range x.postings {
+		x.terms = append(x.terms, term)
+	}
+	sort.Strings(x.terms)
+}
+
+// save writes the index file if anything changed since the last save.
+func (x *searchIndex) save() error {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	if !x.dirty {
+		return nil
+	}
+	return x.saveLocked()
+}
+
+// saveLocked atomically replaces the index file. It holds prompt and
+// response text, so only the user may read it. The caller holds x.mu.
+func (x *searchIndex) saveLocked() error {
+	x.saved = time.Now()
+	if x.path == "" {
+		x.dirty = false
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(x.path), 0700); err != nil {
+		return err
+	}
+	tmp := x.path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	err = gob.NewEncoder(f).Encode(searchIndexFile{Version: searchIndexVersion, Docs: x.docs})
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp, x.path)
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	x.dirty = false
+	return nil
+}
+
+// indexTranscript continues indexing a transcript from prev, or from the
+// start when prev is nil or the file was truncated or replaced. prev is
+// not modified.
+func indexTranscript(fpath string, info os.FileInfo, prev *searchDoc) (*searchDoc, error) {
+	doc := &searchDoc{
+		SessionID: strings.TrimSuffix(filepath.Base(fpath), ".jsonl"),
+		Terms:     make(map[string][]int32),
+	}
+	if prev != nil && sameStart(fpath, info, prev.Offset, prev.Head) {
+		*doc = *prev
+		doc.Messages = append([]searchMessage(nil), prev.Messages...)
+		doc.Terms = make(map[string][]int32, len(prev.Terms))
+		for term, msgs := range prev.Terms {
+			doc.Terms[term] = append([]int32(nil), msgs...)
+		}
+	}
+
+	f, err := os.Open(fpath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	if _, err := f.Seek(doc.Offset, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	// Sub-agent transcripts belong to the session recorded in their lines
+	isAgent := isAgentTranscript(fpath) && doc.SessionID == strings.TrimSuffix(filepath.Base(fpath), ".jsonl")
+
+	reader := bufio.NewReaderSize(f, 64*1024)
+	for {
+		raw, err := reader.ReadBytes('\n')
+		if len(raw) > 0 {
+			var line transcriptLine
+			jsonErr := json.Unmarshal(raw, &line)
+			if err == io.EOF && jsonErr != nil {
+				// Incomplete last line
+				break
+			}
+			offset := doc.Offset
+			doc.Offset += int64(len(raw))
+			doc.Lines++
+			if jsonErr == nil {
+				if isAgent && line.SessionID != "" {
+					doc.SessionID = line.SessionID
+					isAgent = false
+				}
+				if line.Cwd != "" && !line.IsSidechain {
+					doc.Cwd = line.Cwd
+				}
+				doc.add(line, offset)
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	head, err := fileHead(fpath, doc.Offset)
+	if err != nil {
+		return nil, err
+	}
+	doc.Size = info.Size()
+	doc.ModTime = info.ModTime()
+	doc.Head = head
+	return doc, nil
+}
+
+// add indexes the text of a transcript line found at offset.
+func (d *searchDoc) add(line transcriptLine, offset int64) {
+	text := searchText(line)
+	if text == "" {
+		return
+	}
+	tokens := searchTokens(text)
+	if len(tokens) == 0 {
+		return
+	}
+
+	ts, _ := parseTimestamp(line.Timestamp)
+	msg := int32(len(d.Messages))
+	d.Messages = append(d.Messages, searchMessage{Offset: offset, Line: d.Lines, Time: ts})
+	for _, token := range tokens {
+		d.Terms[token] = append(d.Terms[token], msg)
+	}
+}
+
+// searchText returns the prompt or response text of a transcript line,
+// or "" for tool calls, tool results and injected context.
+func searchText(line transcriptLine) string {
+	if line.Message == nil || line.IsMeta {
+		return ""
+	}
+	switch line.Type {
+	case "user":
+		var prompt string
+		if err := json.Unmarshal(line.Message.Content, &prompt); err == nil {
+			return strings.TrimSpace(prompt)
+		}
+	case "assistant":
+	default:
+		return ""
+	}
+
+	var blocks []transcriptContent
+	if err := json.Unmarshal(line.Message.Content, &blocks); err != nil {
+		return ""
+	}
+	var parts []string
+	for _, block := range blocks {
+		if block.Type == "text" && strings.TrimSpace(block.Text) != "" {
+			parts = append(parts, strings.TrimSpace(block.Text))
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+// searchTokens returns the distinct lowercased words of text.
+func searchTokens(text string) []string {
+	seen := make(map[string]bool)
+	var tokens []string
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), isSearchSeparator) {
+		n := utf8.RuneCountInString(word)
+		if n < minSearchToken || n > maxSearchToken || seen[word] {
+			continue
+		}
+		seen[word] = true
+		tokens = append(tokens, word)
+	}
+	return tokens
+}
+
+// isSearchSeparator reports whether r separates words.
+func isSearchSeparator(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_'
+}
+
+// search returns the sessions whose messages contain every word of the
+// query, most recent first, and the problems found updating the index.
+// Each word also matches words it prefixes, so results appear while a
+// word is being typed.
+func (x *searchIndex) search(paths []string, q string, limit int) ([]SearchResult, []ParseError) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	errs := x.update(paths)
+
+	words := searchTokens(q)
+	if len(words) == 0 {
+		return nil, errs
+	}
+
+	var matched map[searchPosting]bool
+	for _, word := range words {
+		next := make(map[searchPosting]bool)
+		i := sort.SearchStrings(x.terms, word)
+		for ; i < len(x.terms) && strings.HasPrefix(x.terms[i], word); i++ {
+			for _, p := range x.postings[x.terms[i]] {
+				if matched == nil || matched[p] {
+					next[p] = true
+				}
+			}
+		}
+		matched = next
+		if len(matched) == 0 {
+			return nil, errs
+		}
+	}
+
+	// Keep the most recent message of each session
+	type hit struct {
+		posting searchPosting
+		msg     searchMessage
+		count   int
+	}
+	sessions := make(map[string]*hit)
+	for p := range matched {
+		doc := x.docs[p.doc]
+		msg := doc.Messages[p.msg]
+		h := sessions[doc.SessionID]
+		if h == nil {
+			sessions[doc.SessionID] = &hit{posting: p, msg: msg, count: 1}
+			continue
+		}
+		h.count++
+		if msg.Time.After(h.msg.Time) || (msg.Time.Equal(h.msg.Time) && p.msg > h.posting.msg) {
+			h.posting, h.msg = p, msg
+		}
+	}
+
+	hits := make([]*hit, 0, len(sessions))
+	for _, h := range sessions {
+		hits = append(hits, h)
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		ti, tj := hits[i].msg.Time, hits[j].msg.Time
+		if !ti.Equal(tj) {
+			return ti.After(tj)
+		}
+		return x.docs[hits[i].posting.doc].SessionID < x.docs[hits[j].posting.doc].SessionID
+	})
+	if limit > 0 && len(hits) > limit {
+		hits = hits[:limit]
+	}
+
+	results := make([]SearchResult, 0, len(hits))
+	for _, h := range hits {
+		doc := x.docs[h.posting.doc]
+		line, err := readTranscriptLine(h.posting.doc, h.msg.Offset)
+		if err != nil {
+			continue
+		}
+		snippet, matches := searchSnippet(searchText(line), words)
+		results = append(results, SearchResult{
+			SessionID:   doc.SessionID,
+			ProjectPath: doc.Cwd,
+			ProjectName: projectName(doc.Cwd),
+			Path:        h.posting.doc,
+			Line:        h.msg.Line,
+			Role:        line.Type,
+			Timestamp:   h.msg.Time,
+			Snippet:     snippet,
+			Matches:     matches,
+			Hits:        h.count,
+		})
+	}
+	return results, errs
+}
+
+// readTranscriptLine reads the transcript line starting at offset.
+func readTranscriptLine(fpath string, offset int64) (transcriptLine, error) {
+	var line transcriptLine
+	f, err := os.Open(fpath)
+	if err != nil {
+		return line, err
+	}
+	defer f.Close()
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return line, err
+	}
+	raw, err := bufio.NewReaderSize(f, 64*1024).ReadBytes('\n')
+	if err != nil && err != io.EOF {
+		return line, err
+	}
+	err = json.Unmarshal(raw, &line)
+	return line, err
+}
+
+// searchSnippet returns a single-line excerpt of text around the first
+// word matching the query, with the byte ranges of every matching word.
+func searchSnippet(text string, words []string) (string, [][2]int) {
+	runes := []rune(strings.Join(strings.Fields(text), " "))
+	lower := []rune(strings.ToLower(string(runes)))
+	if len(lower) != len(runes) {
+		// Case folding changed the length; match on the original text
+		lower = runes
+	}
+
+	// Rune ranges of words starting with a query word
+	var ranges [][2]int
+	for start := 0; start < len(lower); {
+		if isSearchSeparator(lower[start]) {
+			start++
+			continue
+		}
+		end := start
+		for end < len(lower) && !isSearchSeparator(lower[end]) {
+			end++
+		}
+		word := string(lower[start:end])
+		for _, w := range words {
+			if strings.HasPrefix(word, w) {
+				ranges = append(ranges, [2]int{start, start + utf8.RuneCountInString(w)})
+				break
+			}
+		}
+		start = end
+	}
+
+	// Start a little before the first match
+	from := 0
+	if len(ranges) > 0 {
+		from = max(ranges[0][0]-searchSnippetLen/4, 0)
+	}
+	to := min(from+searchSnippetLen, len(runes))
+	from = max(to-searchSnippetLen, 0)
+
+	prefix, suffix := "", ""
+	if from > 0 {
+		prefix = "…"
+	}
+	if to < len(runes) {
+		suffix = "…"
+	}
+	snippet := prefix + string(runes[from:to]) + suffix
+
+	// Convert rune ranges within the window to byte ranges of the snippet
+	var matches [][2]int
+	base := len(prefix)
+	for _, r := range ranges {
+		if r[0] < from || r[1] > to {
+			continue
+		}
+		start := base + len(string(runes[from:r[0]]))
+		matches = append(matches, [2]int{start, start + len(string(runes[r[0]:r[1]]))})
+	}
+	return snippet, matches
+}
@@ -2,14 +2,20 @@ package data
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 )
 
-const sessionsIndexPattern = "~/.claude/projects/*/sessions-index.json"
+// DefaultClaudeDir is the Claude Code data directory used when none is configured.
+const DefaultClaudeDir = "~/.claude"
+
+// sessionsIndexGlob matches session indexes relative to a Claude root.
+const sessionsIndexGlob = "projects/*/sessions-index.json"
 
 // sessionsIndexFile represents the structure of sessions-index.json
 type sessionsIndexFile struct {
@@ -18,100 +24,309 @@ type sessionsIndexFile struct {
 
 // sessionEntryJSON represents the JSON structure of a session entry
 type sessionEntryJSON struct {
-	SessionID    string  `json:"sessionId"`
-	ProjectPath  string  `json:"projectPath"`
-	Summary      string  `json:"summary"`
-	FirstPrompt  string  `json:"firstPrompt"`
-	MessageCount int     `json:"messageCount"`
-	Created      string  `json:"created"`
-	Modified     string  `json:"modified"`
-	GitBranch    *string `json:"gitBranch"`
-}
-
-// ParseSessions parses all sessions-index.json files and returns session entries.
-func ParseSessions() []SessionEntry {
-	pattern := expandPath(sessionsIndexPattern)
-	indexFiles, err := filepath.Glob(pattern)
-	if err != nil {
+	SessionID    string        `json:"sessionId"`
+	FullPath     string        `json:"fullPath"`
+	ProjectPath  string        `json:"projectPath"`
+	Summary      string        `json:"summary"`
+	FirstPrompt  string        `json:"firstPrompt"`
+	MessageCount int           `json:"messageCount"`
+	Created      jsonTimestamp `json:"created"`
+	Modified     jsonTimestamp `json:"modified"`
+	GitBranch    *string       `json:"gitBranch"`
+}
+
+// jsonTimestamp holds a timestamp written either as a string or as a
+// number of epoch milliseconds.
+type jsonTimestamp string
+
+// UnmarshalJSON accepts a JSON string, number or null.
+func (t *jsonTimestamp) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		*t = jsonTimestamp(s)
 		return nil
 	}
+	var n json.Number
+	if err := json.Unmarshal(b, &n); err != nil {
+		return err
+	}
+	*t = jsonTimestamp(n)
+	return nil
+}
 
-	var sessions []SessionEntry
+// sessionsIndex holds the sessions of a sessions-index.json file and the
+// problems found in its entries.
+type sessionsIndex struct {
+	Sessions []SessionEntry
+	Errors   []ParseError
+}
 
-	for _, fpath := range indexFiles {
-		data, err := os.ReadFile(fpath)
-		if err != nil {
-			continue
+// parseSessionsIndex parses a single sessions-index.json file.
+func parseSessionsIndex(fpath string) (sessionsIndex, error) {
+	var index sessionsIndex
+	data, err := os.ReadFile(fpath)
+	if err != nil {
+		return index, err
+	}
+
+	// Try to parse as struct with entries field first
+	var indexFile sessionsIndexFile
+	if err := json.Unmarshal(data, &indexFile); err != nil {
+		// Try parsing as array directly
+		var entries []sessionEntryJSON
+		if err := json.Unmarshal(data, &entries); err != nil {
+			return index, err
 		}
+		indexFile.Entries = entries
+	}
 
-		// Try to parse as struct with entries field first
-		var indexFile sessionsIndexFile
-		if err := json.Unmarshal(data, &indexFile); err != nil {
-			// Try parsing as array directly
-			var entries []sessionEntryJSON
-			if err := json.Unmarshal(data, &entries); err != nil {
-				continue
-			}
-			indexFile.Entries = entries
+	index.Sessions = make([]SessionEntry, 0, len(indexFile.Entries))
+	for _, entry := range indexFile.Entries {
+		transcriptPath := entry.FullPath
+		if transcriptPath == "" {
+			transcriptPath = filepath.Join(filepath.Dir(fpath), entry.SessionID+".jsonl")
 		}
 
-		for _, entry := range indexFile.Entries {
-			summary := entry.Summary
-			if summary == "" {
-				summary = entry.FirstPrompt
-			}
-			if len(summary) > 100 {
-				summary = summary[:100]
-			}
+		session := SessionEntry{
+			SessionID:    entry.SessionID,
+			ProjectPath:  entry.ProjectPath,
+			ProjectName:  projectName(entry.ProjectPath),
+			Summary:      sessionSummary(entry.Summary, entry.FirstPrompt),
+			MessageCount: entry.MessageCount,
+			Created:      index.timestamp(fpath, entry.SessionID, "created", string(entry.Created)),
+			Modified:     index.timestamp(fpath, entry.SessionID, "modified", string(entry.Modified)),
+			GitBranch:    entry.GitBranch,
 
-			projectName := filepath.Base(entry.ProjectPath)
-			if projectName == "" || projectName == "." {
-				projectName = "Unknown"
-			}
+			Source:         rootOf(fpath),
+			TranscriptPath: transcriptPath,
+		}
+		index.Sessions = append(index.Sessions, session)
+	}
 
-			session := SessionEntry{
-				SessionID:    entry.SessionID,
-				ProjectPath:  entry.ProjectPath,
-				ProjectName:  projectName,
-				Summary:      summary,
-				MessageCount: entry.MessageCount,
-				Created:      parseTimestamp(entry.Created),
-				Modified:     parseTimestamp(entry.Modified),
-				GitBranch:    entry.GitBranch,
-			}
-			sessions = append(sessions, session)
+	return index, nil
+}
+
+// timestamp parses a session timestamp, recording an error if it is
+// invalid. Invalid and missing timestamps are left unknown (zero).
+func (x *sessionsIndex) timestamp(fpath, sessionID, field, ts string) time.Time {
+	t, ok := parseTimestamp(ts)
+	if !ok && ts != "" {
+		msg := fmt.Sprintf("session %s: invalid %s timestamp %q", sessionID, field, ts)
+		x.Errors = append(x.Errors, newParseError(ErrorTimestamp, fpath, 0, msg))
+	}
+	return t
+}
+
+// AddTranscriptSessions returns sessions extended with those found only
+// in transcripts, for projects without a usable sessions-index.json.
+// Indexed sessions whose transcript saw activity after the index was
+// written take their modified time and message count from the transcript,
+// and unknown created times are taken from the transcript too.
+func AddTranscriptSessions(sessions []SessionEntry, transcripts map[string]TranscriptStats) []SessionEntry {
+	indexed := make(map[string]bool, len(sessions))
+	for i := range sessions {
+		indexed[sessions[i].SessionID] = true
+		stats, ok := transcripts[sessions[i].SessionID]
+		if !ok {
+			continue
+		}
+		if stats.LastActive.After(sessions[i].Modified) {
+			sessions[i].Modified = stats.LastActive
+			sessions[i].MessageCount = max(sessions[i].MessageCount, stats.MessageCount)
+		}
+		if sessions[i].Created.IsZero() {
+			sessions[i].Created = stats.Started
+		}
+	}
+
+	var ids []string
+	for id, stats := range transcripts {
+		// Transcripts holding only summaries or sub-agent chatter are not sessions
+		if !indexed[id] && stats.MessageCount > 0 {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		stats := transcripts[id]
+		projectPath := stats.Cwd
+		if projectPath == "" {
+			projectPath = filepath.Base(filepath.Dir(stats.Path))
+		}
+		var branch *string
+		if stats.GitBranch != "" {
+			b := stats.GitBranch
+			branch = &b
 		}
+
+		sessions = append(sessions, SessionEntry{
+			SessionID:    id,
+			ProjectPath:  projectPath,
+			ProjectName:  projectName(projectPath),
+			Summary:      sessionSummary(stats.Summary, stats.FirstPrompt),
+			MessageCount: stats.MessageCount,
+			Created:      stats.Started,
+			Modified:     stats.LastActive,
+			GitBranch:    branch,
+
+			Source:         rootOf(stats.Path),
+			TranscriptPath: stats.Path,
+		})
 	}
 
 	return sessions
 }
 
+// sessionSummary returns the text shown for a session, falling back to
+// its first prompt when it has no summary.
+func sessionSummary(summary, firstPrompt string) string {
+	if summary == "" {
+		summary = firstPrompt
+	}
+	if len(summary) > 100 {
+		summary = summary[:100]
+	}
+	return summary
+}
+
+// projectName returns the display name of a project path.
+func projectName(projectPath string) string {
+	name := filepath.Base(projectPath)
+	if name == "" || name == "." {
+		return "Unknown"
+	}
+	return name
+}
+
+// DailyFromSessions sums sessions by the local date each started, oldest
+// first. Sessions whose start is unknown are left out.
+func DailyFromSessions(sessions []SessionEntry) []DailyActivity {
+	daily := make(map[string]*DailyActivity)
+	for _, session := range sessions {
+		addSessionDay(daily, session)
+	}
+	return sortedDays(daily)
+}
+
+// addSessionDay adds a session to the activity of the day it started.
+func addSessionDay(daily map[string]*DailyActivity, session SessionEntry) {
+	started := session.StartTime()
+	if started.IsZero() {
+		return
+	}
+	date := started.Format("2006-01-02")
+	day, ok := daily[date]
+	if !ok {
+		day = &DailyActivity{Date: date}
+		daily[date] = day
+	}
+	day.SessionCount++
+	day.MessageCount += session.MessageCount
+	for _, stats := range session.Tools {
+		day.ToolCallCount += stats.Calls
+	}
+	day.Tokens.Add(session.Tokens)
+	day.TokenCount = day.Tokens.Total()
+	day.Cost += session.Cost
+}
+
+// sortedDays returns daily activity oldest first.
+func sortedDays(daily map[string]*DailyActivity) []DailyActivity {
+	days := make([]DailyActivity, 0, len(daily))
+	for _, day := range daily {
+		days = append(days, *day)
+	}
+	sort.Slice(days, func(i, j int) bool {
+		return days[i].Date < days[j].Date
+	})
+	return days
+}
+
 // AggregateProjects aggregates sessions into project summaries.
 func AggregateProjects(sessions []SessionEntry) []ProjectSummary {
 	projects := make(map[string]*ProjectSummary)
+	branches := make(map[string]map[string]bool)
+	models := make(map[string]map[string]bool)
+	daily := make(map[string]map[string]*DailyActivity)
+	commits := make(map[string]map[string]bool)
 
 	for _, session := range sessions {
 		key := session.ProjectPath
-		if existing, ok := projects[key]; ok {
-			existing.SessionCount++
-			existing.TotalMessages += session.MessageCount
-			if session.Modified.After(existing.LastActivity) {
-				existing.LastActivity = session.Modified
+		p, ok := projects[key]
+		if !ok {
+			p = &ProjectSummary{
+				ProjectName:    session.ProjectName,
+				ProjectPath:    session.ProjectPath,
+				Tools:          make(map[string]ToolUsage),
+				Files:          make(map[string]FileStats),
+				BranchSessions: make(map[string]int),
 			}
-		} else {
-			projects[key] = &ProjectSummary{
-				ProjectName:   session.ProjectName,
-				ProjectPath:   session.ProjectPath,
-				SessionCount:  1,
-				TotalMessages: session.MessageCount,
-				LastActivity:  session.Modified,
+			projects[key] = p
+			branches[key] = make(map[string]bool)
+			models[key] = make(map[string]bool)
+			daily[key] = make(map[string]*DailyActivity)
+			commits[key] = make(map[string]bool)
+		}
+
+		p.SessionCount++
+		p.TotalMessages += session.MessageCount
+		p.TotalCost += session.Cost
+		p.Tokens.Add(session.Tokens)
+		if session.Modified.After(p.LastActivity) {
+			p.LastActivity = session.Modified
+		}
+		started := session.StartTime()
+		if !started.IsZero() && (p.FirstActivity.IsZero() || started.Before(p.FirstActivity)) {
+			p.FirstActivity = started
+		}
+		if session.TimesKnown() {
+			p.TotalDuration += session.Duration()
+			p.TimedSessions++
+		}
+		if session.GitBranch != nil && *session.GitBranch != "" {
+			branches[key][*session.GitBranch] = true
+			p.BranchSessions[*session.GitBranch]++
+		}
+		for model := range session.Models {
+			models[key][model] = true
+		}
+		if session.Git != nil {
+			p.HasGit = true
+			// Concurrent sessions on a branch share commits; count them once
+			for _, c := range session.Git.Commits {
+				if commits[key][c.Hash] {
+					continue
+				}
+				commits[key][c.Hash] = true
+				p.Commits++
+				p.LinesAdded += c.Added
+				p.LinesRemoved += c.Removed
 			}
 		}
+
+		for name, stats := range session.Tools {
+			usage := p.Tools[name]
+			usage.Name = name
+			usage.Sessions++
+			usage.add(stats)
+			p.Tools[name] = usage
+		}
+		for path, stats := range session.Files {
+			file := p.Files[path]
+			file.add(stats)
+			p.Files[path] = file
+		}
+
+		addSessionDay(daily[key], session)
 	}
 
 	// Convert to slice and sort by last activity
 	result := make([]ProjectSummary, 0, len(projects))
-	for _, p := range projects {
+	for key, p := range projects {
+		p.Branches = sortedKeys(branches[key])
+		p.Models = sortedKeys(models[key])
+		p.Daily = sortedDays(daily[key])
 		result = append(result, *p)
 	}
 
@@ -122,32 +337,89 @@ func AggregateProjects(sessions []SessionEntry) []ProjectSummary {
 	return result
 }
 
-// parseTimestamp parses an ISO timestamp string to time.Time.
-func parseTimestamp(ts string) time.Time {
-	if ts == "" {
-		return time.Now()
+// sortedKeys returns the keys of a set in order.
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
 	}
+	sort.Strings(keys)
+	return keys
+}
 
-	// Handle ISO format with Z suffix
-	if strings.HasSuffix(ts, "Z") {
-		ts = ts[:len(ts)-1] + "+00:00"
+// timestampFormats lists the textual timestamp layouts accepted, tried in order.
+var timestampFormats = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999Z0700",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999",
+	time.RFC1123Z,
+	time.RFC1123,
+}
+
+// parseTimestamp parses an ISO timestamp or epoch number to time.Time.
+// Epoch numbers may be in seconds, milliseconds, microseconds or
+// nanoseconds, told apart by magnitude. Empty or unparseable input
+// returns the zero time and false; callers treat it as unknown.
+func parseTimestamp(ts string) (time.Time, bool) {
+	ts = strings.TrimSpace(ts)
+	if ts == "" {
+		return time.Time{}, false
 	}
 
-	// Try various formats
-	formats := []string{
-		time.RFC3339,
-		time.RFC3339Nano,
-		"2006-01-02T15:04:05",
-		"2006-01-02T15:04:05.000",
+	if n, err := strconv.ParseInt(ts, 10, 64); err == nil {
+		return parseEpoch(n), true
+	}
+	if secs, frac, ok := strings.Cut(ts, "."); ok && len(frac) <= 9 && strings.Trim(secs+frac, "0123456789") == "" {
+		// Fractional epoch seconds
+		sec, errSec := strconv.ParseInt(secs, 10, 64)
+		nsec, errNsec := strconv.ParseInt(frac+strings.Repeat("0", 9-len(frac)), 10, 64)
+		if errSec == nil && errNsec == nil {
+			return time.Unix(sec, nsec), true
+		}
 	}
 
-	for _, format := range formats {
+	for _, format := range timestampFormats {
 		if t, err := time.Parse(format, ts); err == nil {
-			return t
+			return t, true
 		}
 	}
 
-	return time.Now()
+	return time.Time{}, false
+}
+
+// parseEpoch converts an epoch number to a time, guessing its unit from
+// how many digits it has.
+func parseEpoch(n int64) time.Time {
+	switch abs := max(n, -n); {
+	case abs < 1e11:
+		return time.Unix(n, 0)
+	case abs < 1e14:
+		return time.UnixMilli(n)
+	case abs < 1e17:
+		return time.UnixMicro(n)
+	default:
+		return time.Unix(0, n)
+	}
+}
+
+// globRoots matches a pattern relative to each Claude root.
+func globRoots(roots []string, pattern string) []string {
+	var paths []string
+	for _, root := range roots {
+		matches, err := filepath.Glob(filepath.Join(expandPath(root), pattern))
+		if err != nil {
+			continue
+		}
+		paths = append(paths, matches...)
+	}
+	return paths
+}
+
+// rootOf returns the Claude root of a file under projects/<project>/.
+func rootOf(fpath string) string {
+	return filepath.Dir(filepath.Dir(filepath.Dir(fpath)))
 }
 
 // expandPath expands ~ to the user's home directory.
@@ -0,0 +1,47 @@
+package data
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTimestamp(t *testing.T) {
+	want := time.Date(2026, 10, 5, 9, 30, 15, 0, time.UTC)
+	tests := []struct {
+		in     string
+		want   time.Time
+		wantOK bool
+	}{
+		{"2026-10-05T09:30:15Z", want, true},
+		{"2026-10-05T09:30:15.250Z", want.Add(250 * time.Millisecond), true},
+		{"2026-10-05T11:30:15+02:00", want, true},
+		{"2026-10-05T11:30:15+0200", want, true},
+		{"2026-10-05 09:30:15Z", want, true},
+		{" 2026-10-05T09:30:15Z ", want, true},
+		{"Mon, 05 Oct 2026 09:30:15 +0000", want, true},
+		{"1791192615", want, true},
+		{"1791192615250", want.Add(250 * time.Millisecond), true},
+		{"1791192615250000", want.Add(250 * time.Millisecond), true},
+		{"1791192615250000000", want.Add(250 * time.Millisecond), true},
+		{"1791192615.25", want.Add(250 * time.Millisecond), true},
+		{"", time.Time{}, false},
+		{"  ", time.Time{}, false},
+		{"yesterday", time.Time{}, false},
+		{"2026-13-05T09:30:15Z", time.Time{}, false},
+		{"1791192615.abc", time.Time{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseTimestamp(tt.in)
+		if ok != tt.wantOK || !got.Equal(tt.want) {
+			t.Errorf("parseTimestamp(%q) = %s, %v, want %s, %v", tt.in, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestParseTimestampWithoutZone(t *testing.T) {
+	// Timestamps without a zone are read as UTC
+	got, ok := parseTimestamp("2026-10-05T09:30:15")
+	if want := time.Date(2026, 10, 5, 9, 30, 15, 0, time.UTC); !ok || !got.Equal(want) {
+		t.Errorf("parseTimestamp() = %s, %v, want %s", got, ok, want)
+	}
+}
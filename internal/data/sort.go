@@ -0,0 +1,169 @@
+package data
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SessionSortField is a field sessions can be sorted by.
+type SessionSortField int
+
+const (
+	SessionSortByTime SessionSortField = iota
+	SessionSortByMessages
+	SessionSortByProject
+	SessionSortByTokens
+	SessionSortByCost
+	sessionSortFieldCount
+)
+
+// Next returns the field after f, wrapping around to the first.
+func (f SessionSortField) Next() SessionSortField {
+	return (f + 1) % sessionSortFieldCount
+}
+
+// String returns the display name of the field.
+func (f SessionSortField) String() string {
+	switch f {
+	case SessionSortByMessages:
+		return "Messages"
+	case SessionSortByProject:
+		return "Project"
+	case SessionSortByTokens:
+		return "Tokens"
+	case SessionSortByCost:
+		return "Cost"
+	}
+	return "Time"
+}
+
+// ParseSessionSort returns the field with the given name, ignoring case.
+func ParseSessionSort(name string) (SessionSortField, error) {
+	for f := SessionSortField(0); f < sessionSortFieldCount; f++ {
+		if strings.EqualFold(name, f.String()) {
+			return f, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown session sort %q (want %s)", name, sortNames(int(sessionSortFieldCount), func(i int) string {
+		return SessionSortField(i).String()
+	}))
+}
+
+// SessionLess reports whether session a sorts before b by field, in
+// descending order if desc. Sessions with an unknown time stay last when
+// sorting by time.
+func SessionLess(a, b *SessionEntry, field SessionSortField, desc bool) bool {
+	if field == SessionSortByTime && a.Modified.IsZero() != b.Modified.IsZero() {
+		return b.Modified.IsZero()
+	}
+	if desc {
+		a, b = b, a
+	}
+	switch field {
+	case SessionSortByMessages:
+		return a.MessageCount < b.MessageCount
+	case SessionSortByProject:
+		return a.ProjectName < b.ProjectName
+	case SessionSortByTokens:
+		return a.Tokens.Total() < b.Tokens.Total()
+	case SessionSortByCost:
+		return a.Cost < b.Cost
+	}
+	return a.Modified.Before(b.Modified)
+}
+
+// SortSessions sorts sessions by field, keeping the order of equal ones.
+func SortSessions(sessions []SessionEntry, field SessionSortField, desc bool) {
+	sort.SliceStable(sessions, func(i, j int) bool {
+		return SessionLess(&sessions[i], &sessions[j], field, desc)
+	})
+}
+
+// ProjectSortField is a field projects can be sorted by.
+type ProjectSortField int
+
+const (
+	ProjectSortByActivity ProjectSortField = iota
+	ProjectSortByName
+	ProjectSortBySessions
+	ProjectSortByMessages
+	ProjectSortByCost
+	ProjectSortByCommits
+	projectSortFieldCount
+)
+
+// Next returns the field after f, wrapping around to the first.
+func (f ProjectSortField) Next() ProjectSortField {
+	return (f + 1) % projectSortFieldCount
+}
+
+// String returns the display name of the field.
+func (f ProjectSortField) String() string {
+	switch f {
+	case ProjectSortByName:
+		return "Name"
+	case ProjectSortBySessions:
+		return "Sessions"
+	case ProjectSortByMessages:
+		return "Messages"
+	case ProjectSortByCost:
+		return "Cost"
+	case ProjectSortByCommits:
+		return "Commits"
+	}
+	return "Activity"
+}
+
+// ParseProjectSort returns the field with the given name, ignoring case.
+func ParseProjectSort(name string) (ProjectSortField, error) {
+	for f := ProjectSortField(0); f < projectSortFieldCount; f++ {
+		if strings.EqualFold(name, f.String()) {
+			return f, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown project sort %q (want %s)", name, sortNames(int(projectSortFieldCount), func(i int) string {
+		return ProjectSortField(i).String()
+	}))
+}
+
+// ProjectLess reports whether project a sorts before b by field, in
+// descending order if desc. Projects with unknown activity stay last when
+// sorting by activity.
+func ProjectLess(a, b *ProjectSummary, field ProjectSortField, desc bool) bool {
+	if field == ProjectSortByActivity && a.LastActivity.IsZero() != b.LastActivity.IsZero() {
+		return b.LastActivity.IsZero()
+	}
+	if desc {
+		a, b = b, a
+	}
+	switch field {
+	case ProjectSortByName:
+		return a.ProjectName < b.ProjectName
+	case ProjectSortBySessions:
+		return a.SessionCount < b.SessionCount
+	case ProjectSortByMessages:
+		return a.TotalMessages < b.TotalMessages
+	case ProjectSortByCost:
+		return a.TotalCost < b.TotalCost
+	case ProjectSortByCommits:
+		return a.Commits < b.Commits
+	}
+	return a.LastActivity.Before(b.LastActivity)
+}
+
+// SortProjects sorts projects by field, keeping the order of equal ones.
+func SortProjects(projects []ProjectSummary, field ProjectSortField, desc bool) {
+	sort.SliceStable(projects, func(i, j int) bool {
+		return ProjectLess(&projects[i], &projects[j], field, desc)
+	})
+}
+
+// sortNames lists the lowercase names of n sort fields for error messages.
+func sortNames(n int, name func(int) string) string {
+	names := make([]string, n)
+	for i := range names {
+		names[i] = strings.ToLower(name(i))
+	}
+	return strings.Join(names, ", ")
+}
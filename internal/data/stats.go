@@ -3,10 +3,12 @@ package data
 import (
 	"encoding/json"
 	"os"
+	"path/filepath"
 	"sort"
 )
 
-const statsCachePath = "~/.claude/stats-cache.json"
+// statsCacheName is the stats cache file in a Claude root.
+const statsCacheName = "stats-cache.json"
 
 // statsCacheFile represents the structure of stats-cache.json
 type statsCacheFile struct {
@@ -21,33 +23,45 @@ type dailyActivityJSON struct {
 	ToolCallCount int    `json:"toolCallCount"`
 }
 
-// ParseStatsCache parses stats-cache.json and returns daily activity data.
-func ParseStatsCache() []DailyActivity {
-	fpath := expandPath(statsCachePath)
+// ParseStatsCache parses the stats-cache.json of every Claude root and
+// returns daily activity data summed across roots. Roots without a stats
+// cache are skipped; caches that cannot be parsed are returned as errors.
+func ParseStatsCache(roots []string) ([]DailyActivity, []ParseError) {
+	days := make(map[string]*DailyActivity)
+	var errs []ParseError
+	for _, root := range roots {
+		fpath := filepath.Join(expandPath(root), statsCacheName)
 
-	data, err := os.ReadFile(fpath)
-	if err != nil {
-		return nil
-	}
+		data, err := os.ReadFile(fpath)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			errs = append(errs, newParseError(ErrorUnreadable, fpath, 0, errorText(err)))
+			continue
+		}
 
-	var cacheFile statsCacheFile
-	if err := json.Unmarshal(data, &cacheFile); err != nil {
-		return nil
-	}
+		var cacheFile statsCacheFile
+		if err := json.Unmarshal(data, &cacheFile); err != nil {
+			errs = append(errs, newParseError(ErrorUnreadable, fpath, 0, errorText(err)))
+			continue
+		}
 
-	result := make([]DailyActivity, 0, len(cacheFile.DailyActivity))
-	for _, day := range cacheFile.DailyActivity {
-		// Estimate tokens: rough heuristic based on messages and tool calls
-		// Average message ~500 chars, tool call ~200 chars, 4 chars per token
-		estimatedTokens := (day.MessageCount*500 + day.ToolCallCount*200) / 4
-		activity := DailyActivity{
-			Date:          day.Date,
-			MessageCount:  day.MessageCount,
-			SessionCount:  day.SessionCount,
-			ToolCallCount: day.ToolCallCount,
-			TokenCount:    estimatedTokens,
+		for _, day := range cacheFile.DailyActivity {
+			activity, ok := days[day.Date]
+			if !ok {
+				activity = &DailyActivity{Date: day.Date}
+				days[day.Date] = activity
+			}
+			activity.MessageCount += day.MessageCount
+			activity.SessionCount += day.SessionCount
+			activity.ToolCallCount += day.ToolCallCount
 		}
-		result = append(result, activity)
+	}
+
+	result := make([]DailyActivity, 0, len(days))
+	for _, activity := range days {
+		result = append(result, *activity)
 	}
 
 	// Sort by date (most recent last for sparkline display)
@@ -55,5 +69,5 @@ func ParseStatsCache() []DailyActivity {
 		return result[i].Date < result[j].Date
 	})
 
-	return result
+	return result, errs
 }
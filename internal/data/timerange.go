@@ -0,0 +1,234 @@
+package data
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RangeKind is how a TimeRange chooses its days.
+type RangeKind int
+
+const (
+	RangeAll      RangeKind = iota
+	RangeToday              // Today
+	RangeWeek               // The calendar week so far
+	RangeMonth              // The calendar month so far
+	RangeYear               // The year so far
+	RangeLastDays           // The last Days days, today included
+	RangeDates              // From..To
+)
+
+// TimeRange represents a time filter for data: all time, a calendar period
+// up to today, the last few days, or explicit dates.
+type TimeRange struct {
+	Kind RangeKind
+	Days int       // Length of RangeLastDays
+	From time.Time // First day of RangeDates, zero if open
+	To   time.Time // Last day of RangeDates, included, zero if open
+}
+
+// The fixed time ranges.
+var (
+	TimeAll   = TimeRange{Kind: RangeAll}
+	TimeToday = TimeRange{Kind: RangeToday}
+	TimeWeek  = TimeRange{Kind: RangeWeek}
+	TimeMonth = TimeRange{Kind: RangeMonth}
+	TimeYear  = TimeRange{Kind: RangeYear}
+)
+
+// LastDays returns the range of the last n days, today included.
+func LastDays(n int) TimeRange {
+	return TimeRange{Kind: RangeLastDays, Days: max(n, 1)}
+}
+
+// Dates returns the range of the days from from to to, both included.
+// Either may be zero to leave that side open.
+func Dates(from, to time.Time) TimeRange {
+	return TimeRange{Kind: RangeDates, From: startOfDay(from), To: startOfDay(to)}
+}
+
+// weekStart is the first day of calendar weeks.
+var weekStart = time.Monday
+
+// SetWeekStart sets the first day of calendar weeks. It is meant to be
+// called once at startup.
+func SetWeekStart(day time.Weekday) {
+	weekStart = day
+}
+
+// WeekStart returns the first day of calendar weeks.
+func WeekStart() time.Weekday {
+	return weekStart
+}
+
+// StartOfWeek returns midnight on the first day of the calendar week
+// containing t.
+func StartOfWeek(t time.Time) time.Time {
+	day := startOfDay(t)
+	return day.AddDate(0, 0, -((int(day.Weekday()) - int(weekStart) + 7) % 7))
+}
+
+// ParseWeekday returns the weekday with the given English name or its
+// first three letters, ignoring case.
+func ParseWeekday(name string) (time.Weekday, error) {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		full := strings.ToLower(day.String())
+		if n := strings.ToLower(name); n == full || n == full[:3] {
+			return day, nil
+		}
+	}
+	return time.Monday, fmt.Errorf("invalid weekday %q", name)
+}
+
+// startOfDay returns midnight at the start of t's day, or zero for zero.
+func startOfDay(t time.Time) time.Time {
+	if t.IsZero() {
+		return t
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// IsAll returns whether the range covers all time.
+func (tr TimeRange) IsAll() bool {
+	return tr.Period().IsAll()
+}
+
+// String returns a display name for the time range.
+func (tr TimeRange) String() string {
+	switch tr.Kind {
+	case RangeToday:
+		return "Today"
+	case RangeWeek:
+		return "This Week"
+	case RangeMonth:
+		return "This Month"
+	case RangeYear:
+		return "Year to Date"
+	case RangeLastDays:
+		if tr.Days == 1 {
+			return "Last Day"
+		}
+		return fmt.Sprintf("Last %d Days", tr.Days)
+	case RangeDates:
+		return tr.Period().String()
+	}
+	return "All Time"
+}
+
+// Period returns the span of time the range covers now.
+func (tr TimeRange) Period() Period {
+	return tr.PeriodAt(time.Now())
+}
+
+// PeriodAt returns the span of time the range covers at now. Ranges up to
+// today end at midnight tonight.
+func (tr TimeRange) PeriodAt(now time.Time) Period {
+	today := startOfDay(now)
+	tomorrow := today.AddDate(0, 0, 1)
+	switch tr.Kind {
+	case RangeToday:
+		return Period{Start: today, End: tomorrow}
+	case RangeWeek:
+		return Period{Start: StartOfWeek(today), End: tomorrow}
+	case RangeMonth:
+		return Period{Start: today.AddDate(0, 0, 1-today.Day()), End: tomorrow}
+	case RangeYear:
+		return Period{Start: time.Date(today.Year(), time.January, 1, 0, 0, 0, 0, today.Location()), End: tomorrow}
+	case RangeLastDays:
+		return Period{Start: today.AddDate(0, 0, 1-max(tr.Days, 1)), End: tomorrow}
+	case RangeDates:
+		p := Period{Start: tr.From}
+		if !tr.To.IsZero() {
+			p.End = tr.To.AddDate(0, 0, 1)
+		}
+		return p
+	}
+	return Period{}
+}
+
+// PeriodBack returns the period n steps before the range's at now, to
+// compare with: the same part of the day, week, month or year n before,
+// or for other ranges the span of the same number of days n times before.
+// It returns false for ranges open on either side.
+func (tr TimeRange) PeriodBack(now time.Time, n int) (Period, bool) {
+	p := tr.PeriodAt(now)
+	if p.Start.IsZero() || p.End.IsZero() {
+		return Period{}, false
+	}
+	shift := func(t time.Time, n int) time.Time {
+		switch tr.Kind {
+		case RangeToday:
+			return t.AddDate(0, 0, -n)
+		case RangeWeek:
+			return t.AddDate(0, 0, -7*n)
+		case RangeMonth:
+			return t.AddDate(0, -n, 0)
+		case RangeYear:
+			return t.AddDate(-n, 0, 0)
+		}
+		days := int(math.Round(p.End.Sub(p.Start).Hours() / 24))
+		return t.AddDate(0, 0, -days*n)
+	}
+	back := Period{Start: shift(p.Start, n), End: shift(p.End, n)}
+	// Shifting the end into a shorter month can run past the next period
+	if next := shift(p.Start, n-1); back.End.After(next) {
+		back.End = next
+	}
+	return back, true
+}
+
+// ParseTimeRange parses a time range: today, week, month, year or all,
+// the last N days as Nd (e.g. 30d), a single date, or dates as from..to,
+// where either side may be left out and to is included, e.g.
+// 2026-10-01..2026-10-07 or 2026-09-01.. for everything since.
+func ParseTimeRange(s string) (TimeRange, error) {
+	s = strings.TrimSpace(s)
+	switch strings.ToLower(s) {
+	case "today":
+		return TimeToday, nil
+	case "week":
+		return TimeWeek, nil
+	case "month":
+		return TimeMonth, nil
+	case "year", "ytd":
+		return TimeYear, nil
+	case "all", "":
+		return TimeAll, nil
+	}
+
+	if n, ok := strings.CutSuffix(strings.ToLower(s), "d"); ok {
+		days, err := strconv.Atoi(n)
+		if err != nil || days < 1 {
+			return TimeRange{}, fmt.Errorf("invalid number of days %q (want e.g. 30d)", s)
+		}
+		return LastDays(days), nil
+	}
+
+	from, to, ok := strings.Cut(s, "..")
+	if !ok {
+		day, err := time.ParseInLocation("2006-01-02", s, time.Local)
+		if err != nil {
+			return TimeRange{}, fmt.Errorf("invalid range %q (want today, week, month, year, all, Nd, a date or FROM..TO)", s)
+		}
+		return Dates(day, day), nil
+	}
+	var start, end time.Time
+	var err error
+	if from != "" {
+		if start, err = time.ParseInLocation("2006-01-02", from, time.Local); err != nil {
+			return TimeRange{}, fmt.Errorf("invalid range start %q (want YYYY-MM-DD)", from)
+		}
+	}
+	if to != "" {
+		if end, err = time.ParseInLocation("2006-01-02", to, time.Local); err != nil {
+			return TimeRange{}, fmt.Errorf("invalid range end %q (want YYYY-MM-DD)", to)
+		}
+	}
+	if !start.IsZero() && !end.IsZero() && end.Before(start) {
+		return TimeRange{}, fmt.Errorf("invalid range %q: end is before start", s)
+	}
+	return Dates(start, end), nil
+}
@@ -0,0 +1,234 @@
+package data
+
+import (
+	"testing"
+	"time"
+)
+
+// day returns local midnight on a date.
+func day(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, time.Local)
+}
+
+func TestParseTimeRange(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    TimeRange
+		wantErr bool
+	}{
+		{in: "", want: TimeAll},
+		{in: "all", want: TimeAll},
+		{in: "today", want: TimeToday},
+		{in: " WEEK ", want: TimeWeek},
+		{in: "month", want: TimeMonth},
+		{in: "year", want: TimeYear},
+		{in: "ytd", want: TimeYear},
+		{in: "30d", want: LastDays(30)},
+		{in: "1D", want: LastDays(1)},
+		{in: "0d", wantErr: true},
+		{in: "-3d", wantErr: true},
+		{in: "xd", wantErr: true},
+		{in: "2026-10-05", want: Dates(day(2026, 10, 5), day(2026, 10, 5))},
+		{in: "2026-10-01..2026-10-07", want: Dates(day(2026, 10, 1), day(2026, 10, 7))},
+		{in: "2026-10-07..2026-10-07", want: Dates(day(2026, 10, 7), day(2026, 10, 7))},
+		{in: "2026-09-01..", want: Dates(day(2026, 9, 1), time.Time{})},
+		{in: "..2026-09-30", want: Dates(time.Time{}, day(2026, 9, 30))},
+		{in: "..", want: Dates(time.Time{}, time.Time{})},
+		{in: "2026-10-07..2026-10-01", wantErr: true},
+		{in: "2026-13-01..", wantErr: true},
+		{in: "..10/01/2026", wantErr: true},
+		{in: "2026-02-30", wantErr: true},
+		{in: "fortnight", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseTimeRange(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseTimeRange(%q) = %+v, want an error", tt.in, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseTimeRange(%q) error: %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseTimeRange(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseTimeRangeDatesPeriod(t *testing.T) {
+	tr, err := ParseTimeRange("2026-10-01..2026-10-07")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := tr.Period()
+	if !p.Start.Equal(day(2026, 10, 1)) || !p.End.Equal(day(2026, 10, 8)) {
+		t.Errorf("Period() = %v..%v, want 2026-10-01..2026-10-08 exclusive", p.Start, p.End)
+	}
+	if got := p.String(); got != "2026-10-01..2026-10-07" {
+		t.Errorf("String() = %q, want the days as given", got)
+	}
+}
+
+func TestStartOfWeek(t *testing.T) {
+	defer SetWeekStart(WeekStart())
+
+	tests := []struct {
+		start time.Weekday
+		t     time.Time
+		want  time.Time
+	}{
+		// 2026-10-16 is a Friday
+		{time.Monday, time.Date(2026, 10, 16, 15, 4, 5, 0, time.Local), day(2026, 10, 12)},
+		{time.Monday, day(2026, 10, 12), day(2026, 10, 12)},
+		{time.Monday, day(2026, 10, 11), day(2026, 10, 5)},
+		{time.Sunday, time.Date(2026, 10, 16, 15, 4, 5, 0, time.Local), day(2026, 10, 11)},
+		{time.Sunday, day(2026, 10, 11), day(2026, 10, 11)},
+		{time.Sunday, day(2026, 10, 10), day(2026, 10, 4)},
+		{time.Saturday, day(2026, 10, 16), day(2026, 10, 10)},
+		{time.Saturday, day(2026, 10, 10), day(2026, 10, 10)},
+		{time.Friday, day(2026, 10, 16), day(2026, 10, 16)},
+		// Across a month and a year
+		{time.Sunday, day(2026, 11, 3), day(2026, 11, 1)},
+		{time.Sunday, day(2027, 1, 1), day(2026, 12, 27)},
+	}
+	for _, tt := range tests {
+		SetWeekStart(tt.start)
+		if got := StartOfWeek(tt.t); !got.Equal(tt.want) {
+			t.Errorf("StartOfWeek(%s) with weeks from %s = %s, want %s",
+				tt.t.Format("2006-01-02 Mon"), tt.start, got.Format("2006-01-02 Mon"), tt.want.Format("2006-01-02 Mon"))
+		}
+	}
+}
+
+func TestWeekPeriodFollowsWeekStart(t *testing.T) {
+	defer SetWeekStart(WeekStart())
+	SetWeekStart(time.Sunday)
+
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.Local)
+	p := TimeWeek.PeriodAt(now)
+	if !p.Start.Equal(day(2026, 10, 11)) || !p.End.Equal(day(2026, 10, 17)) {
+		t.Errorf("TimeWeek.PeriodAt(%s) = %v..%v, want Sunday 2026-10-11 to tomorrow", now, p.Start, p.End)
+	}
+}
+
+func TestParseWeekday(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Weekday
+		wantErr bool
+	}{
+		{in: "monday", want: time.Monday},
+		{in: "Sunday", want: time.Sunday},
+		{in: "SAT", want: time.Saturday},
+		{in: "thu", want: time.Thursday},
+		{in: "th", wantErr: true},
+		{in: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseWeekday(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseWeekday(%q) error = %v, want error %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("ParseWeekday(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestPeriodBack(t *testing.T) {
+	defer SetWeekStart(WeekStart())
+	SetWeekStart(time.Monday)
+
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	utc := func(year int, month time.Month, d, hour int) time.Time {
+		return time.Date(year, month, d, hour, 0, 0, 0, time.UTC)
+	}
+	ny := func(year int, month time.Month, d, hour int) time.Time {
+		return time.Date(year, month, d, hour, 0, 0, 0, newYork)
+	}
+
+	tests := []struct {
+		name      string
+		tr        TimeRange
+		now       time.Time
+		n         int
+		wantStart time.Time
+		wantEnd   time.Time
+	}{
+		{"yesterday across a month", TimeToday, utc(2026, 3, 1, 10), 1, utc(2026, 2, 28, 0), utc(2026, 3, 1, 0)},
+		{"same days of last week", TimeWeek, utc(2026, 10, 16, 10), 1, utc(2026, 10, 5, 0), utc(2026, 10, 10, 0)},
+		{"month end into a shorter month", TimeMonth, utc(2026, 3, 30, 10), 1, utc(2026, 2, 1, 0), utc(2026, 3, 1, 0)},
+		{"last day of a long month", TimeMonth, utc(2026, 3, 31, 10), 1, utc(2026, 2, 1, 0), utc(2026, 3, 1, 0)},
+		{"two months back from a month end", TimeMonth, utc(2026, 3, 30, 10), 2, utc(2026, 1, 1, 0), utc(2026, 1, 31, 0)},
+		{"month across a year", TimeMonth, utc(2026, 1, 15, 10), 1, utc(2025, 12, 1, 0), utc(2025, 12, 16, 0)},
+		{"year from a leap day", TimeYear, utc(2028, 2, 29, 10), 1, utc(2027, 1, 1, 0), utc(2027, 3, 1, 0)},
+		{"last 30 days", LastDays(30), utc(2026, 10, 16, 10), 1, utc(2026, 8, 18, 0), utc(2026, 9, 17, 0)},
+		{"dates", Dates(utc(2026, 10, 1, 0), utc(2026, 10, 7, 0)), utc(2026, 10, 16, 10), 1, utc(2026, 9, 24, 0), utc(2026, 10, 1, 0)},
+		{"dates three back", Dates(utc(2026, 10, 1, 0), utc(2026, 10, 7, 0)), utc(2026, 10, 16, 10), 3, utc(2026, 9, 10, 0), utc(2026, 9, 17, 0)},
+
+		// Daylight saving time starts on 2026-03-08 and ends on 2026-11-01
+		{"day after the spring change", TimeToday, ny(2026, 3, 9, 12), 1, ny(2026, 3, 8, 0), ny(2026, 3, 9, 0)},
+		{"week across the spring change", TimeWeek, ny(2026, 3, 11, 12), 1, ny(2026, 3, 2, 0), ny(2026, 3, 5, 0)},
+		{"short span across the spring change", LastDays(7), ny(2026, 3, 10, 12), 1, ny(2026, 2, 25, 0), ny(2026, 3, 4, 0)},
+		{"long span across the autumn change", LastDays(7), ny(2026, 11, 3, 12), 1, ny(2026, 10, 21, 0), ny(2026, 10, 28, 0)},
+	}
+	for _, tt := range tests {
+		got, ok := tt.tr.PeriodBack(tt.now, tt.n)
+		if !ok {
+			t.Errorf("%s: PeriodBack(%s, %d) not ok", tt.name, tt.now, tt.n)
+			continue
+		}
+		if !got.Start.Equal(tt.wantStart) || !got.End.Equal(tt.wantEnd) {
+			t.Errorf("%s: PeriodBack(%s, %d) = %s..%s, want %s..%s", tt.name, tt.now, tt.n,
+				got.Start, got.End, tt.wantStart, tt.wantEnd)
+		}
+	}
+}
+
+func TestPeriodBackOpenRanges(t *testing.T) {
+	now := time.Date(2026, 10, 16, 10, 0, 0, 0, time.UTC)
+	for _, tr := range []TimeRange{
+		TimeAll,
+		Dates(day(2026, 10, 1), time.Time{}),
+		Dates(time.Time{}, day(2026, 10, 7)),
+	} {
+		if p, ok := tr.PeriodBack(now, 1); ok {
+			t.Errorf("%+v.PeriodBack() = %s, want none for an open range", tr, p)
+		}
+	}
+}
+
+// Earlier periods never overlap: each ends by the time the next starts.
+func TestPeriodBackDoesNotOverlap(t *testing.T) {
+	defer SetWeekStart(WeekStart())
+	SetWeekStart(time.Sunday)
+
+	ranges := []TimeRange{TimeToday, TimeWeek, TimeMonth, TimeYear, LastDays(7), LastDays(30)}
+	for _, now := range []time.Time{
+		time.Date(2026, 3, 31, 23, 0, 0, 0, time.UTC),
+		time.Date(2026, 5, 31, 1, 0, 0, 0, time.UTC),
+		time.Date(2028, 2, 29, 12, 0, 0, 0, time.UTC),
+	} {
+		for _, tr := range ranges {
+			next := tr.PeriodAt(now)
+			for n := 1; n < 8; n++ {
+				p, ok := tr.PeriodBack(now, n)
+				if !ok {
+					t.Fatalf("%s at %s: PeriodBack(%d) not ok", tr, now, n)
+				}
+				if !p.Start.Before(p.End) || p.End.After(next.Start) {
+					t.Errorf("%s at %s: PeriodBack(%d) = %s..%s, overlapping %s..%s",
+						tr, now, n, p.Start, p.End, next.Start, next.End)
+				}
+				next = p
+			}
+		}
+	}
+}
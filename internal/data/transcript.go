@@ -0,0 +1,180 @@
+package data
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// TranscriptEntryKind represents the kind of a transcript entry.
+type TranscriptEntryKind int
+
+const (
+	EntryUser TranscriptEntryKind = iota
+	EntryAssistant
+	EntryThinking
+	EntryTool
+)
+
+// TranscriptEntry represents one displayable item of a session transcript.
+type TranscriptEntry struct {
+	Kind      TranscriptEntryKind
+	Timestamp time.Time
+	Text      string // Prompt, response or thinking text
+
+	// Tool call fields, set for EntryTool
+	ToolName  string
+	ToolInput string // Compact JSON
+	Result    string
+	IsError   bool
+	HasResult bool
+}
+
+// transcriptContent represents a content block as needed for display.
+type transcriptContent struct {
+	Type      string          `json:"type"`
+	Text      string          `json:"text"`
+	Thinking  string          `json:"thinking"`
+	ID        string          `json:"id"`
+	Name      string          `json:"name"`
+	Input     json.RawMessage `json:"input"`
+	ToolUseID string          `json:"tool_use_id"`
+	Content   json.RawMessage `json:"content"`
+	IsError   bool            `json:"is_error"`
+}
+
+// LoadTranscript reads a session transcript for display.
+// Tool results are attached to the tool call that produced them.
+func LoadTranscript(fpath string) ([]TranscriptEntry, error) {
+	f, err := os.Open(fpath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []TranscriptEntry
+	toolIndex := make(map[string]int) // tool use ID -> entry index
+
+	reader := bufio.NewReaderSize(f, 64*1024)
+	for {
+		raw, err := reader.ReadBytes('\n')
+		if len(raw) > 0 {
+			var line transcriptLine
+			if jsonErr := json.Unmarshal(raw, &line); jsonErr == nil && line.Message != nil {
+				ts, _ := parseTimestamp(line.Timestamp)
+				switch line.Type {
+				case "user":
+					entries = appendUserContent(entries, toolIndex, line.Message.Content, ts)
+				case "assistant":
+					entries = appendAssistantContent(entries, toolIndex, line.Message.Content, ts)
+				}
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return entries, err
+		}
+	}
+
+	return entries, nil
+}
+
+// appendUserContent adds a user prompt or attaches tool results.
+func appendUserContent(entries []TranscriptEntry, toolIndex map[string]int, content json.RawMessage, ts time.Time) []TranscriptEntry {
+	var prompt string
+	if err := json.Unmarshal(content, &prompt); err == nil {
+		if strings.TrimSpace(prompt) != "" {
+			entries = append(entries, TranscriptEntry{Kind: EntryUser, Timestamp: ts, Text: prompt})
+		}
+		return entries
+	}
+
+	var blocks []transcriptContent
+	if err := json.Unmarshal(content, &blocks); err != nil {
+		return entries
+	}
+
+	for _, block := range blocks {
+		switch block.Type {
+		case "text":
+			if strings.TrimSpace(block.Text) != "" {
+				entries = append(entries, TranscriptEntry{Kind: EntryUser, Timestamp: ts, Text: block.Text})
+			}
+		case "tool_result":
+			idx, ok := toolIndex[block.ToolUseID]
+			if !ok {
+				continue
+			}
+			entries[idx].Result = toolResultText(block.Content)
+			entries[idx].IsError = block.IsError
+			entries[idx].HasResult = true
+		}
+	}
+	return entries
+}
+
+// appendAssistantContent adds response text, thinking and tool calls.
+func appendAssistantContent(entries []TranscriptEntry, toolIndex map[string]int, content json.RawMessage, ts time.Time) []TranscriptEntry {
+	var blocks []transcriptContent
+	if err := json.Unmarshal(content, &blocks); err != nil {
+		return entries
+	}
+
+	for _, block := range blocks {
+		switch block.Type {
+		case "text":
+			if strings.TrimSpace(block.Text) != "" {
+				entries = append(entries, TranscriptEntry{Kind: EntryAssistant, Timestamp: ts, Text: block.Text})
+			}
+		case "thinking":
+			if strings.TrimSpace(block.Thinking) != "" {
+				entries = append(entries, TranscriptEntry{Kind: EntryThinking, Timestamp: ts, Text: block.Thinking})
+			}
+		case "tool_use":
+			toolIndex[block.ID] = len(entries)
+			entries = append(entries, TranscriptEntry{
+				Kind:      EntryTool,
+				Timestamp: ts,
+				ToolName:  block.Name,
+				ToolInput: compactJSON(block.Input),
+			})
+		}
+	}
+	return entries
+}
+
+// toolResultText extracts text from a tool result, which is either a
+// string or an array of content blocks.
+func toolResultText(content json.RawMessage) string {
+	var text string
+	if err := json.Unmarshal(content, &text); err == nil {
+		return text
+	}
+
+	var blocks []transcriptContent
+	if err := json.Unmarshal(content, &blocks); err != nil {
+		return ""
+	}
+	var parts []string
+	for _, block := range blocks {
+		if block.Type == "text" {
+			parts = append(parts, block.Text)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+// compactJSON returns raw JSON without insignificant whitespace.
+func compactJSON(raw json.RawMessage) string {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, raw); err != nil {
+		return string(raw)
+	}
+	return buf.String()
+}
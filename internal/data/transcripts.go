@@ -0,0 +1,588 @@
+package data
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/moshe-exe/lazyvibe/internal/config"
+)
+
+// transcriptsGlob matches session transcripts relative to a Claude root.
+const transcriptsGlob = "projects/*/*.jsonl"
+
+const syntheticModel = "<synthetic>"
+
+// TranscriptStats holds aggregates computed from a session transcript.
+type TranscriptStats struct {
+	SessionID string
+	Path      string
+	Usage     TokenUsage
+	Models    ModelTokens
+	Messages  map[string]int         // Assistant messages per model
+	Daily     map[string]ModelTokens // Keyed by local date (2006-01-02)
+	Tools     map[string]ToolStats
+	Files     map[string]FileStats // Files edited or read, keyed by path
+
+	// Session metadata from the main conversation, used when the
+	// session is missing from sessions-index.json
+	Cwd          string
+	GitBranch    string
+	Summary      string // Latest summary line
+	FirstPrompt  string
+	Started      time.Time // Zero if no line had a timestamp
+	LastActive   time.Time
+	MessageCount int
+
+	// Errors lists malformed lines.
+	Errors []ParseError
+
+	// lines counts the lines read so far, for error line numbers.
+	lines int
+
+	// pendingTools tracks tool calls awaiting their result, keyed by tool use ID.
+	pendingTools map[string]pendingTool
+
+	// lastMessageID is the ID of the latest assistant message. Streamed
+	// messages repeat it and their usage block on every content line, and
+	// are counted once.
+	lastMessageID string
+}
+
+// pendingTool records when a tool call was issued.
+type pendingTool struct {
+	Name    string
+	Started time.Time
+}
+
+// ModelTokens maps model names to their token usage.
+type ModelTokens map[string]TokenUsage
+
+// add accumulates usage for a model.
+func (mt ModelTokens) add(model string, usage TokenUsage) {
+	u := mt[model]
+	u.Add(usage)
+	mt[model] = u
+}
+
+// Total returns the usage summed across all models.
+func (mt ModelTokens) Total() TokenUsage {
+	var total TokenUsage
+	for _, u := range mt {
+		total.Add(u)
+	}
+	return total
+}
+
+// Cost returns the USD cost of the usage using the given price table.
+// Models without a price contribute nothing.
+func (mt ModelTokens) Cost(prices config.PriceTable) float64 {
+	cost := 0.0
+	for model, u := range mt {
+		if price, ok := prices.Lookup(model); ok {
+			cost += price.Cost(u.Input, u.Output, u.CacheCreation, u.CacheRead)
+		}
+	}
+	return cost
+}
+
+// transcriptLine represents the fields of a transcript line we aggregate.
+type transcriptLine struct {
+	Type        string             `json:"type"`
+	SessionID   string             `json:"sessionId"`
+	Timestamp   string             `json:"timestamp"`
+	Cwd         string             `json:"cwd"`
+	GitBranch   string             `json:"gitBranch"`
+	IsSidechain bool               `json:"isSidechain"`
+	IsMeta      bool               `json:"isMeta"`
+	Summary     string             `json:"summary"`
+	Message     *transcriptMessage `json:"message"`
+}
+
+// transcriptMessage represents the API message embedded in a transcript line.
+type transcriptMessage struct {
+	ID      string          `json:"id"`
+	Role    string          `json:"role"`
+	Model   string          `json:"model"`
+	Usage   *usageJSON      `json:"usage"`
+	Content json.RawMessage `json:"content"`
+}
+
+// contentBlock represents a block in a message's content array.
+type contentBlock struct {
+	Type      string          `json:"type"`
+	ID        string          `json:"id"`
+	Name      string          `json:"name"`
+	Input     json.RawMessage `json:"input"`
+	ToolUseID string          `json:"tool_use_id"`
+	IsError   bool            `json:"is_error"`
+}
+
+// usageJSON represents the JSON structure of an API usage block
+type usageJSON struct {
+	InputTokens              int `json:"input_tokens"`
+	OutputTokens             int `json:"output_tokens"`
+	CacheCreationInputTokens int `json:"cache_creation_input_tokens"`
+	CacheReadInputTokens     int `json:"cache_read_input_tokens"`
+}
+
+// mergeTranscripts keys transcript stats by session ID, folding sub-agent
+// transcripts into their parent session. A transcript found under several
+// Claude roots, such as a synced copy, counts once: the copy with the
+// latest activity is kept. The inputs are not modified.
+func mergeTranscripts(parsed []TranscriptStats) map[string]TranscriptStats {
+	// Copies share their project directory and file name
+	copies := make(map[string]int, len(parsed))
+	var unique []TranscriptStats
+	for _, stats := range parsed {
+		key := filepath.Join(filepath.Base(filepath.Dir(stats.Path)), filepath.Base(stats.Path))
+		i, ok := copies[key]
+		if !ok {
+			copies[key] = len(unique)
+			unique = append(unique, stats)
+		} else if stats.LastActive.After(unique[i].LastActive) {
+			unique[i] = stats
+		}
+	}
+
+	result := make(map[string]TranscriptStats, len(unique))
+	merged := make(map[string]bool)
+	for _, stats := range unique {
+		existing, ok := result[stats.SessionID]
+		if !ok {
+			result[stats.SessionID] = stats
+			continue
+		}
+		if !merged[stats.SessionID] {
+			existing = existing.clone()
+			merged[stats.SessionID] = true
+		}
+		existing.merge(stats)
+		result[stats.SessionID] = existing
+	}
+	return result
+}
+
+// newTranscriptStats returns empty stats for a transcript file.
+func newTranscriptStats(fpath string) TranscriptStats {
+	return TranscriptStats{
+		SessionID: strings.TrimSuffix(filepath.Base(fpath), ".jsonl"),
+		Path:      fpath,
+		Models:    make(ModelTokens),
+		Messages:  make(map[string]int),
+		Daily:     make(map[string]ModelTokens),
+		Tools:     make(map[string]ToolStats),
+		Files:     make(map[string]FileStats),
+
+		pendingTools: make(map[string]pendingTool),
+	}
+}
+
+// readFrom accumulates the lines of a transcript starting at byte offset
+// and returns the offset just past the last line consumed. A trailing
+// line that is still being written is left for the next read.
+func (s *TranscriptStats) readFrom(fpath string, offset int64) (int64, error) {
+	f, err := os.Open(fpath)
+	if err != nil {
+		return offset, err
+	}
+	defer f.Close()
+
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return offset, err
+	}
+
+	// Sub-agent transcripts are named agent-<id>.jsonl and belong to
+	// the session recorded in their lines
+	isAgent := isAgentTranscript(fpath) && s.SessionID == strings.TrimSuffix(filepath.Base(fpath), ".jsonl")
+
+	reader := bufio.NewReaderSize(f, 64*1024)
+	for {
+		raw, err := reader.ReadBytes('\n')
+		if len(raw) > 0 {
+			var line transcriptLine
+			jsonErr := json.Unmarshal(raw, &line)
+			if err == io.EOF && jsonErr != nil {
+				// Incomplete last line
+				break
+			}
+			offset += int64(len(raw))
+			s.lines++
+			switch {
+			case jsonErr == nil:
+				if isAgent && line.SessionID != "" {
+					s.SessionID = line.SessionID
+					isAgent = false
+				}
+				s.addLine(line)
+			case len(bytes.TrimSpace(raw)) > 0:
+				s.Errors = append(s.Errors, newParseError(ErrorMalformed, fpath, s.lines, jsonErr.Error()))
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return offset, err
+		}
+	}
+
+	return offset, nil
+}
+
+// addLine accumulates the metadata, tool calls and usage block of a line.
+func (s *TranscriptStats) addLine(line transcriptLine) {
+	if line.Type == "summary" {
+		s.addMetadata(line, time.Time{}, false)
+		return
+	}
+	if line.Message == nil {
+		return
+	}
+	ts, ok := parseTimestamp(line.Timestamp)
+	if !ok && line.Timestamp != "" {
+		msg := fmt.Sprintf("invalid timestamp %q", line.Timestamp)
+		s.Errors = append(s.Errors, newParseError(ErrorTimestamp, s.Path, s.lines, msg))
+	}
+	repeat := false
+	if line.Type == "assistant" {
+		repeat = line.Message.ID != "" && line.Message.ID == s.lastMessageID
+		s.lastMessageID = line.Message.ID
+	}
+	s.addMetadata(line, ts, repeat)
+	s.addToolBlocks(line.Message.Content, ts)
+
+	if line.Type != "assistant" || line.Message.Usage == nil || repeat {
+		return
+	}
+	// Claude Code records locally generated errors under a synthetic model
+	if line.Message.Model == syntheticModel {
+		return
+	}
+
+	usage := TokenUsage{
+		Input:         line.Message.Usage.InputTokens,
+		Output:        line.Message.Usage.OutputTokens,
+		CacheCreation: line.Message.Usage.CacheCreationInputTokens,
+		CacheRead:     line.Message.Usage.CacheReadInputTokens,
+	}
+	s.Usage.Add(usage)
+	s.Models.add(line.Message.Model, usage)
+	s.Messages[line.Message.Model]++
+
+	// Usage at an unknown time counts toward totals but no day
+	if ts.IsZero() {
+		return
+	}
+	date := ts.Local().Format("2006-01-02")
+	if s.Daily[date] == nil {
+		s.Daily[date] = make(ModelTokens)
+	}
+	s.Daily[date].add(line.Message.Model, usage)
+}
+
+// addMetadata records session details from main conversation lines,
+// with ts the line's parsed timestamp, zero if unknown, and repeat set
+// for further lines of a streamed assistant message. Sub-agent
+// (sidechain) lines belong to their parent's conversation.
+func (s *TranscriptStats) addMetadata(line transcriptLine, ts time.Time, repeat bool) {
+	if line.Type == "summary" {
+		if line.Summary != "" {
+			s.Summary = line.Summary
+		}
+		return
+	}
+	if line.IsSidechain || line.Message == nil {
+		return
+	}
+
+	if !ts.IsZero() {
+		if s.Started.IsZero() || ts.Before(s.Started) {
+			s.Started = ts
+		}
+		if ts.After(s.LastActive) {
+			s.LastActive = ts
+		}
+	}
+	if line.Cwd != "" {
+		s.Cwd = line.Cwd
+	}
+	if line.GitBranch != "" {
+		s.GitBranch = line.GitBranch
+	}
+
+	switch line.Type {
+	case "user":
+		prompt := promptText(line.Message.Content)
+		if prompt == "" || line.IsMeta {
+			// Tool results and injected context are not prompts
+			return
+		}
+		s.MessageCount++
+		if s.FirstPrompt == "" {
+			s.FirstPrompt = prompt
+		}
+	case "assistant":
+		if !repeat {
+			s.MessageCount++
+		}
+	}
+}
+
+// promptText returns the text of a user message, or "" if it only
+// carries tool results.
+func promptText(content json.RawMessage) string {
+	var text string
+	if err := json.Unmarshal(content, &text); err == nil {
+		return strings.TrimSpace(text)
+	}
+
+	var blocks []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	}
+	if err := json.Unmarshal(content, &blocks); err != nil {
+		return ""
+	}
+	for _, block := range blocks {
+		if block.Type == "text" && strings.TrimSpace(block.Text) != "" {
+			return strings.TrimSpace(block.Text)
+		}
+	}
+	return ""
+}
+
+// addToolBlocks records tool_use blocks and pairs tool_result blocks with them.
+// Streamed assistant messages spread their blocks across lines, so every
+// line is inspected even when its usage block is a duplicate.
+func (s *TranscriptStats) addToolBlocks(content json.RawMessage, ts time.Time) {
+	if len(content) == 0 || content[0] != '[' {
+		return
+	}
+	var blocks []contentBlock
+	if err := json.Unmarshal(content, &blocks); err != nil {
+		return
+	}
+
+	for _, block := range blocks {
+		switch block.Type {
+		case "tool_use":
+			stats := s.Tools[block.Name]
+			stats.Calls++
+			s.Tools[block.Name] = stats
+			s.pendingTools[block.ID] = pendingTool{Name: block.Name, Started: ts}
+			s.addFileTool(block.Name, block.Input)
+		case "tool_result":
+			pending, ok := s.pendingTools[block.ToolUseID]
+			if !ok {
+				continue
+			}
+			delete(s.pendingTools, block.ToolUseID)
+
+			stats := s.Tools[pending.Name]
+			stats.Completed++
+			if block.IsError {
+				stats.Errors++
+			}
+			// Latency is only known when both ends have a timestamp
+			if !ts.IsZero() && !pending.Started.IsZero() {
+				if latency := ts.Sub(pending.Started); latency > 0 {
+					stats.Latency += latency
+				}
+			}
+			s.Tools[pending.Name] = stats
+		}
+	}
+}
+
+// fileToolInput holds the file a tool call operates on.
+type fileToolInput struct {
+	FilePath     string `json:"file_path"`
+	NotebookPath string `json:"notebook_path"`
+}
+
+// addFileTool counts a call to a tool that edits or reads a file.
+func (s *TranscriptStats) addFileTool(name string, input json.RawMessage) {
+	edit := false
+	switch name {
+	case "Edit", "Write", "MultiEdit", "NotebookEdit":
+		edit = true
+	case "Read":
+	default:
+		return
+	}
+
+	var in fileToolInput
+	if err := json.Unmarshal(input, &in); err != nil {
+		return
+	}
+	path := in.FilePath
+	if path == "" {
+		path = in.NotebookPath
+	}
+	if path == "" {
+		return
+	}
+	if !filepath.IsAbs(path) && s.Cwd != "" {
+		path = filepath.Join(s.Cwd, path)
+	}
+	path = filepath.Clean(path)
+
+	stats := s.Files[path]
+	if edit {
+		stats.Edits++
+	} else {
+		stats.Reads++
+	}
+	s.Files[path] = stats
+}
+
+// clone returns a copy of s that shares no maps with it.
+func (s TranscriptStats) clone() TranscriptStats {
+	c := TranscriptStats{
+		SessionID: s.SessionID,
+		Path:      s.Path,
+		Models:    make(ModelTokens, len(s.Models)),
+		Messages:  make(map[string]int, len(s.Messages)),
+		Daily:     make(map[string]ModelTokens, len(s.Daily)),
+		Tools:     make(map[string]ToolStats, len(s.Tools)),
+		Files:     make(map[string]FileStats, len(s.Files)),
+
+		pendingTools: make(map[string]pendingTool),
+	}
+	c.merge(s)
+	return c
+}
+
+// merge folds another transcript's aggregates into s. Metadata missing
+// from s is taken from other, so sub-agent transcripts merged first do
+// not hide their parent's.
+func (s *TranscriptStats) merge(other TranscriptStats) {
+	if isAgentTranscript(s.Path) && !isAgentTranscript(other.Path) {
+		s.Path = other.Path
+	}
+	if s.Cwd == "" {
+		s.Cwd = other.Cwd
+	}
+	if s.GitBranch == "" {
+		s.GitBranch = other.GitBranch
+	}
+	if s.Summary == "" {
+		s.Summary = other.Summary
+	}
+	if s.FirstPrompt == "" {
+		s.FirstPrompt = other.FirstPrompt
+	}
+	if !other.Started.IsZero() && (s.Started.IsZero() || other.Started.Before(s.Started)) {
+		s.Started = other.Started
+	}
+	if other.LastActive.After(s.LastActive) {
+		s.LastActive = other.LastActive
+	}
+	s.MessageCount += other.MessageCount
+	s.Errors = append(s.Errors, other.Errors...)
+
+	s.Usage.Add(other.Usage)
+	for model, usage := range other.Models {
+		s.Models.add(model, usage)
+	}
+	for model, count := range other.Messages {
+		s.Messages[model] += count
+	}
+	for name, tool := range other.Tools {
+		stats := s.Tools[name]
+		stats.add(tool)
+		s.Tools[name] = stats
+	}
+	for path, file := range other.Files {
+		stats := s.Files[path]
+		stats.add(file)
+		s.Files[path] = stats
+	}
+	for date, models := range other.Daily {
+		if s.Daily[date] == nil {
+			s.Daily[date] = make(ModelTokens)
+		}
+		for model, usage := range models {
+			s.Daily[date].add(model, usage)
+		}
+	}
+}
+
+// isAgentTranscript returns whether a transcript belongs to a sub-agent.
+func isAgentTranscript(fpath string) bool {
+	return strings.HasPrefix(filepath.Base(fpath), "agent-")
+}
+
+// AttachSessionUsage sets measured token usage and cost on each session.
+func AttachSessionUsage(sessions []SessionEntry, transcripts map[string]TranscriptStats, prices config.PriceTable) {
+	for i := range sessions {
+		stats, ok := transcripts[sessions[i].SessionID]
+		if !ok {
+			continue
+		}
+		sessions[i].Tokens = stats.Usage
+		sessions[i].Cost = stats.Models.Cost(prices)
+		sessions[i].Models = make(map[string]ModelStats, len(stats.Models))
+		for model, usage := range stats.Models {
+			sessions[i].Models[model] = ModelStats{
+				Messages: stats.Messages[model],
+				Tokens:   usage,
+				Cost:     ModelTokens{model: usage}.Cost(prices),
+			}
+		}
+		sessions[i].Tools = stats.Tools
+		sessions[i].Files = stats.Files
+		if sessions[i].TranscriptPath == "" {
+			sessions[i].TranscriptPath = stats.Path
+		}
+	}
+}
+
+// MergeDailyUsage adds measured token usage and cost to daily activity.
+// Days that only appear in transcripts are appended.
+func MergeDailyUsage(activity []DailyActivity, transcripts map[string]TranscriptStats, prices config.PriceTable) []DailyActivity {
+	daily := make(map[string]ModelTokens)
+	for _, stats := range transcripts {
+		for date, models := range stats.Daily {
+			if daily[date] == nil {
+				daily[date] = make(ModelTokens)
+			}
+			for model, usage := range models {
+				daily[date].add(model, usage)
+			}
+		}
+	}
+
+	result := make([]DailyActivity, 0, len(activity))
+	for _, day := range activity {
+		day.Tokens = daily[day.Date].Total()
+		day.TokenCount = day.Tokens.Total()
+		day.Cost = daily[day.Date].Cost(prices)
+		delete(daily, day.Date)
+		result = append(result, day)
+	}
+
+	for date, models := range daily {
+		usage := models.Total()
+		result = append(result, DailyActivity{
+			Date:       date,
+			TokenCount: usage.Total(),
+			Tokens:     usage,
+			Cost:       models.Cost(prices),
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Date < result[j].Date
+	})
+
+	return result
+}
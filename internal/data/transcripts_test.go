@@ -0,0 +1,228 @@
+package data
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const (
+	testLine1 = `{"type":"assistant","sessionId":"s1","timestamp":"2026-10-05T09:30:00Z","message":{"id":"m1","model":"claude-sonnet-4-5","usage":{"input_tokens":10,"output_tokens":20}}}` + "\n"
+	testLine2 = `{"type":"assistant","sessionId":"s1","timestamp":"2026-10-05T09:31:00Z","message":{"id":"m2","model":"claude-sonnet-4-5","usage":{"input_tokens":5,"output_tokens":7}}}` + "\n"
+)
+
+// appendFile appends text to a file, creating it if needed.
+func appendFile(t *testing.T, fpath, text string) {
+	t.Helper()
+	f, err := os.OpenFile(fpath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if _, err := f.WriteString(text); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReadFromOffsets(t *testing.T) {
+	fpath := filepath.Join(t.TempDir(), "s1.jsonl")
+	stats := newTranscriptStats(fpath)
+
+	// A trailing line still being written is left for the next read
+	partial := testLine2[:40]
+	appendFile(t, fpath, testLine1+partial)
+	offset, err := stats.readFrom(fpath, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := int64(len(testLine1)); offset != want {
+		t.Fatalf("first read offset = %d, want %d", offset, want)
+	}
+	if stats.Usage.Output != 20 || len(stats.Errors) != 0 {
+		t.Fatalf("first read output = %d with %d errors, want 20 and none", stats.Usage.Output, len(stats.Errors))
+	}
+
+	// Once finished, the line is read from where the last read stopped
+	appendFile(t, fpath, testLine2[40:])
+	offset, err = stats.readFrom(fpath, offset)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := int64(len(testLine1) + len(testLine2)); offset != want {
+		t.Fatalf("second read offset = %d, want %d", offset, want)
+	}
+	if stats.Usage.Input != 15 || stats.Usage.Output != 27 || stats.Messages["claude-sonnet-4-5"] != 2 {
+		t.Errorf("second read usage = %+v, %d messages, want 15 in, 27 out, 2 messages",
+			stats.Usage, stats.Messages["claude-sonnet-4-5"])
+	}
+
+	// Nothing new leaves the offset and stats alone
+	again, err := stats.readFrom(fpath, offset)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again != offset || stats.Usage.Output != 27 {
+		t.Errorf("third read offset = %d, output = %d, want %d and 27", again, stats.Usage.Output, offset)
+	}
+}
+
+func TestReadFromMalformedLines(t *testing.T) {
+	fpath := filepath.Join(t.TempDir(), "s1.jsonl")
+	appendFile(t, fpath, testLine1+"not json\n\n"+testLine2)
+
+	stats := newTranscriptStats(fpath)
+	offset, err := stats.readFrom(fpath, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := int64(len(testLine1) + len("not json\n\n") + len(testLine2)); offset != want {
+		t.Errorf("offset = %d, want %d", offset, want)
+	}
+	if stats.Usage.Output != 27 {
+		t.Errorf("output = %d, want 27 from the valid lines", stats.Usage.Output)
+	}
+	if len(stats.Errors) != 1 || stats.Errors[0].Line != 2 {
+		t.Errorf("errors = %+v, want one on line 2", stats.Errors)
+	}
+}
+
+// assistantLine returns a transcript line of an assistant message.
+func assistantLine(id, model string, input, output int, extra string) string {
+	return fmt.Sprintf(`{"type":"assistant","sessionId":"s1","timestamp":"2026-10-05T09:30:00Z",%s"message":{"id":%q,"model":%q,"usage":{"input_tokens":%d,"output_tokens":%d,"cache_creation_input_tokens":1,"cache_read_input_tokens":2}}}`,
+		extra, id, model, input, output)
+}
+
+const toolResultLine = `{"type":"user","sessionId":"s1","timestamp":"2026-10-05T09:30:01Z","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"t1"}]}}`
+
+func TestUsageAggregation(t *testing.T) {
+	const sonnet, opus = "claude-sonnet-4-5", "claude-opus-4-1"
+	tests := []struct {
+		name     string
+		lines    []string
+		models   ModelTokens
+		messages map[string]int // Per model
+		count    int            // MessageCount
+	}{
+		{
+			name:     "distinct messages",
+			lines:    []string{assistantLine("m1", sonnet, 10, 20, ""), assistantLine("m2", sonnet, 5, 7, "")},
+			models:   ModelTokens{sonnet: {Input: 15, Output: 27, CacheCreation: 2, CacheRead: 4}},
+			messages: map[string]int{sonnet: 2},
+			count:    2,
+		},
+		{
+			name: "streamed lines counted once",
+			lines: []string{
+				assistantLine("m1", sonnet, 10, 20, ""),
+				assistantLine("m1", sonnet, 10, 20, ""),
+				assistantLine("m1", sonnet, 10, 20, ""),
+			},
+			models:   ModelTokens{sonnet: {Input: 10, Output: 20, CacheCreation: 1, CacheRead: 2}},
+			messages: map[string]int{sonnet: 1},
+			count:    1,
+		},
+		{
+			name: "tool results between streamed lines",
+			lines: []string{
+				assistantLine("m1", sonnet, 10, 20, ""),
+				toolResultLine,
+				assistantLine("m1", sonnet, 10, 20, ""),
+			},
+			models:   ModelTokens{sonnet: {Input: 10, Output: 20, CacheCreation: 1, CacheRead: 2}},
+			messages: map[string]int{sonnet: 1},
+			count:    1,
+		},
+		{
+			name:     "lines without an ID each count",
+			lines:    []string{assistantLine("", sonnet, 10, 20, ""), assistantLine("", sonnet, 10, 20, "")},
+			models:   ModelTokens{sonnet: {Input: 20, Output: 40, CacheCreation: 2, CacheRead: 4}},
+			messages: map[string]int{sonnet: 2},
+			count:    2,
+		},
+		{
+			name:     "synthetic messages carry no usage",
+			lines:    []string{assistantLine("m1", sonnet, 10, 20, ""), assistantLine("e1", syntheticModel, 0, 0, "")},
+			models:   ModelTokens{sonnet: {Input: 10, Output: 20, CacheCreation: 1, CacheRead: 2}},
+			messages: map[string]int{sonnet: 1},
+			count:    2,
+		},
+		{
+			name:     "models kept apart",
+			lines:    []string{assistantLine("m1", sonnet, 10, 20, ""), assistantLine("m2", opus, 1, 2, "")},
+			models:   ModelTokens{sonnet: {Input: 10, Output: 20, CacheCreation: 1, CacheRead: 2}, opus: {Input: 1, Output: 2, CacheCreation: 1, CacheRead: 2}},
+			messages: map[string]int{sonnet: 1, opus: 1},
+			count:    2,
+		},
+		{
+			name:     "sub-agent usage but no message",
+			lines:    []string{assistantLine("m1", sonnet, 10, 20, `"isSidechain":true,`)},
+			models:   ModelTokens{sonnet: {Input: 10, Output: 20, CacheCreation: 1, CacheRead: 2}},
+			messages: map[string]int{sonnet: 1},
+			count:    0,
+		},
+	}
+	for _, tt := range tests {
+		fpath := filepath.Join(t.TempDir(), "s1.jsonl")
+		appendFile(t, fpath, strings.Join(tt.lines, "\n")+"\n")
+		stats := newTranscriptStats(fpath)
+		if _, err := stats.readFrom(fpath, 0); err != nil {
+			t.Fatal(err)
+		}
+		if len(stats.Errors) > 0 {
+			t.Errorf("%s: errors %v", tt.name, stats.Errors)
+		}
+
+		var total TokenUsage
+		for _, usage := range tt.models {
+			total.Add(usage)
+		}
+		if stats.Usage != total {
+			t.Errorf("%s: usage = %+v, want %+v", tt.name, stats.Usage, total)
+		}
+		if !reflect.DeepEqual(stats.Models, tt.models) {
+			t.Errorf("%s: models = %+v, want %+v", tt.name, stats.Models, tt.models)
+		}
+		if !reflect.DeepEqual(stats.Messages, tt.messages) {
+			t.Errorf("%s: messages = %v, want %v", tt.name, stats.Messages, tt.messages)
+		}
+		if stats.MessageCount != tt.count {
+			t.Errorf("%s: message count = %d, want %d", tt.name, stats.MessageCount, tt.count)
+		}
+	}
+}
+
+func TestMergeTranscriptsCountsCopiesOnce(t *testing.T) {
+	read := func(fpath, text string) TranscriptStats {
+		if err := os.MkdirAll(filepath.Dir(fpath), 0o700); err != nil {
+			t.Fatal(err)
+		}
+		appendFile(t, fpath, text)
+		stats := newTranscriptStats(fpath)
+		if _, err := stats.readFrom(fpath, 0); err != nil {
+			t.Fatal(err)
+		}
+		return stats
+	}
+	dir := t.TempDir()
+	old := read(filepath.Join(dir, "a", "projects", "app", "s1.jsonl"), testLine1)
+	synced := read(filepath.Join(dir, "b", "projects", "app", "s1.jsonl"), testLine1+testLine2)
+	agent := read(filepath.Join(dir, "b", "projects", "app", "agent-x.jsonl"),
+		strings.Replace(testLine2, `"m2"`, `"m3"`, 1))
+	other := read(filepath.Join(dir, "b", "projects", "other", "s1.jsonl"), testLine1)
+
+	// The copy with the latest activity counts, along with the sub-agent
+	got := mergeTranscripts([]TranscriptStats{old, synced, agent})
+	if s := got["s1"]; len(got) != 1 || s.Usage.Output != 34 || s.Path != synced.Path {
+		t.Errorf("merged = %d sessions, s1 output %d from %s, want 1 with 34 from %s",
+			len(got), s.Usage.Output, s.Path, synced.Path)
+	}
+
+	// The same file name in another project is not a copy
+	got = mergeTranscripts([]TranscriptStats{old, other})
+	if s := got["s1"]; s.Usage.Output != 40 {
+		t.Errorf("merged output = %d, want 40 from both projects", s.Usage.Output)
+	}
+}
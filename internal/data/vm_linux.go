@@ -0,0 +1,226 @@
+//go:build linux
+
+package data
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// clockTicks is USER_HZ, the unit of CPU times in /proc. It is 100 on
+// every mainstream Linux architecture.
+const clockTicks = 100
+
+// cpuSampleTTL is how long a CPU sample is kept for computing deltas.
+const cpuSampleTTL = time.Minute
+
+// defaultVMPatterns matches common hypervisor processes.
+var defaultVMPatterns = []string{"qemu-system", "firecracker", "cloud-hypervisor", "crosvm"}
+
+// cpuSample records a process's CPU time at a point in time.
+type cpuSample struct {
+	ticks uint64
+	at    time.Time
+}
+
+// procMonitor samples processes by reading /proc directly.
+// CPU% is computed from the change in CPU time since the previous sample.
+type procMonitor struct {
+	mu      sync.Mutex
+	samples map[int]cpuSample
+}
+
+// newProcessMonitor returns the /proc based monitor.
+func newProcessMonitor() processMonitor {
+	return &procMonitor{samples: make(map[int]cpuSample)}
+}
+
+// VMStatus returns the status of the first process whose executable
+// path matches a pattern. Arguments are ignored so that flags naming a
+// hypervisor do not match.
+func (p *procMonitor) VMStatus(patterns []string) VMStatus {
+	for _, pid := range listPIDs() {
+		args := readCmdline(pid)
+		if len(args) == 0 || !matchesPattern(args[0], patterns) {
+			continue
+		}
+		info, ok := p.sample(pid)
+		if !ok {
+			continue
+		}
+		return VMStatus{
+			Running:    true,
+			PID:        &info.PID,
+			CPUPercent: &info.CPUPercent,
+			MemoryMB:   &info.MemoryMB,
+		}
+	}
+	return VMStatus{Running: false}
+}
+
+// ClaudeProcesses lists Claude Code CLI processes.
+func (p *procMonitor) ClaudeProcesses() []ProcessInfo {
+	var procs []ProcessInfo
+	for _, pid := range listPIDs() {
+		args := readCmdline(pid)
+		if !isClaudeCommand(args) {
+			continue
+		}
+		info, ok := p.sample(pid)
+		if !ok {
+			continue
+		}
+		info.Command = strings.Join(args, " ")
+		info.Cwd, _ = os.Readlink(filepath.Join("/proc", strconv.Itoa(pid), "cwd"))
+		info.OpenFiles = openTranscripts(pid)
+		procs = append(procs, info)
+	}
+	return procs
+}
+
+// sample reads a process's CPU and memory usage.
+func (p *procMonitor) sample(pid int) (ProcessInfo, bool) {
+	ticks, startTicks, ok := readCPUTicks(pid)
+	if !ok {
+		return ProcessInfo{}, false
+	}
+	now := time.Now()
+
+	p.mu.Lock()
+	prev, hasPrev := p.samples[pid]
+	p.samples[pid] = cpuSample{ticks: ticks, at: now}
+	for other, s := range p.samples {
+		if now.Sub(s.at) > cpuSampleTTL {
+			delete(p.samples, other)
+		}
+	}
+	p.mu.Unlock()
+
+	info := ProcessInfo{PID: pid, MemoryMB: readRSSMB(pid)}
+	if hasPrev && ticks >= prev.ticks && now.After(prev.at) {
+		cpuSeconds := float64(ticks-prev.ticks) / clockTicks
+		info.CPUPercent = cpuSeconds / now.Sub(prev.at).Seconds() * 100
+	} else if uptime, ok := readUptime(); ok {
+		// First sample: average over the process lifetime
+		elapsed := uptime - float64(startTicks)/clockTicks
+		if elapsed > 0 {
+			info.CPUPercent = float64(ticks) / clockTicks / elapsed * 100
+		}
+	}
+	return info, true
+}
+
+// listPIDs returns the IDs of all running processes.
+func listPIDs() []int {
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		return nil
+	}
+	var pids []int
+	for _, entry := range entries {
+		if pid, err := strconv.Atoi(entry.Name()); err == nil && entry.IsDir() {
+			pids = append(pids, pid)
+		}
+	}
+	return pids
+}
+
+// readCmdline returns a process's arguments.
+func readCmdline(pid int) []string {
+	content, err := os.ReadFile(filepath.Join("/proc", strconv.Itoa(pid), "cmdline"))
+	if err != nil || len(content) == 0 {
+		return nil
+	}
+	var args []string
+	for _, arg := range bytes.Split(bytes.TrimRight(content, "\x00"), []byte{0}) {
+		args = append(args, string(arg))
+	}
+	return args
+}
+
+// openTranscripts returns the JSONL files a process has open.
+func openTranscripts(pid int) []string {
+	fdDir := filepath.Join("/proc", strconv.Itoa(pid), "fd")
+	entries, err := os.ReadDir(fdDir)
+	if err != nil {
+		return nil
+	}
+	var files []string
+	for _, entry := range entries {
+		target, err := os.Readlink(filepath.Join(fdDir, entry.Name()))
+		if err == nil && strings.HasSuffix(target, ".jsonl") {
+			files = append(files, target)
+		}
+	}
+	return files
+}
+
+// readCPUTicks returns a process's user plus system CPU time and its
+// start time since boot, both in clock ticks.
+func readCPUTicks(pid int) (ticks, start uint64, ok bool) {
+	content, err := os.ReadFile(filepath.Join("/proc", strconv.Itoa(pid), "stat"))
+	if err != nil {
+		return 0, 0, false
+	}
+
+	// The command name may contain spaces and parentheses; fields
+	// resume after the last closing parenthesis, starting with state
+	end := bytes.LastIndexByte(content, ')')
+	if end < 0 {
+		return 0, 0, false
+	}
+	fields := strings.Fields(string(content[end+1:]))
+	if len(fields) < 20 {
+		return 0, 0, false
+	}
+
+	utime, err1 := strconv.ParseUint(fields[11], 10, 64)
+	stime, err2 := strconv.ParseUint(fields[12], 10, 64)
+	start, err3 := strconv.ParseUint(fields[19], 10, 64)
+	if err1 != nil || err2 != nil || err3 != nil {
+		return 0, 0, false
+	}
+	return utime + stime, start, true
+}
+
+// readRSSMB returns a process's resident set size in megabytes.
+func readRSSMB(pid int) float64 {
+	content, err := os.ReadFile(filepath.Join("/proc", strconv.Itoa(pid), "status"))
+	if err != nil {
+		return 0
+	}
+	for _, line := range strings.Split(string(content), "\n") {
+		if !strings.HasPrefix(line, "VmRSS:") {
+			continue
+		}
+		fields := strings.Fields(strings.TrimPrefix(line, "VmRSS:"))
+		if len(fields) == 0 {
+			return 0
+		}
+		kb, err := strconv.ParseFloat(fields[0], 64)
+		if err != nil {
+			return 0
+		}
+		return kb / 1024.0
+	}
+	return 0
+}
+
+// readUptime returns the seconds since boot.
+func readUptime() (float64, bool) {
+	content, err := os.ReadFile("/proc/uptime")
+	if err != nil {
+		return 0, false
+	}
+	fields := strings.Fields(string(content))
+	if len(fields) == 0 {
+		return 0, false
+	}
+	uptime, err := strconv.ParseFloat(fields[0], 64)
+	return uptime, err == nil
+}
@@ -0,0 +1,160 @@
+//go:build !linux
+
+package data
+
+import (
+	"context"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultVMPatterns matches the Claude Desktop VM.
+var defaultVMPatterns = []string{vmProcessPattern}
+
+// psMonitor samples processes using pgrep and ps.
+type psMonitor struct{}
+
+// newProcessMonitor returns the pgrep/ps based monitor.
+func newProcessMonitor() processMonitor {
+	return psMonitor{}
+}
+
+// VMStatus returns the status of the first process matching a pattern.
+func (psMonitor) VMStatus(patterns []string) VMStatus {
+	for _, pattern := range patterns {
+		if status := pgrepVMStatus(pattern); status.Running {
+			return status
+		}
+	}
+	return VMStatus{Running: false}
+}
+
+// pgrepVMStatus finds a process matching pattern and reads its usage with ps.
+func pgrepVMStatus(pattern string) VMStatus {
+	// Find VM process using pgrep
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "pgrep", "-f", pattern)
+	output, err := cmd.Output()
+	if err != nil || len(output) == 0 {
+		return VMStatus{Running: false}
+	}
+
+	// Get first PID
+	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+	if len(lines) == 0 {
+		return VMStatus{Running: false}
+	}
+
+	pid, err := strconv.Atoi(lines[0])
+	if err != nil {
+		return VMStatus{Running: false}
+	}
+
+	// Get CPU and memory stats using ps
+	ctx2, cancel2 := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel2()
+
+	psCmd := exec.CommandContext(ctx2, "ps", "-p", strconv.Itoa(pid), "-o", "%cpu=,rss=")
+	psOutput, err := psCmd.Output()
+	if err != nil {
+		pidPtr := pid
+		return VMStatus{Running: true, PID: &pidPtr}
+	}
+
+	parts := strings.Fields(strings.TrimSpace(string(psOutput)))
+	if len(parts) >= 2 {
+		cpuPercent, err1 := strconv.ParseFloat(parts[0], 64)
+		memoryKB, err2 := strconv.Atoi(parts[1])
+
+		pidPtr := pid
+		status := VMStatus{Running: true, PID: &pidPtr}
+
+		if err1 == nil {
+			status.CPUPercent = &cpuPercent
+		}
+		if err2 == nil {
+			memoryMB := float64(memoryKB) / 1024.0
+			status.MemoryMB = &memoryMB
+		}
+
+		return status
+	}
+
+	pidPtr := pid
+	return VMStatus{Running: true, PID: &pidPtr}
+}
+
+// ClaudeProcesses lists Claude Code CLI processes using ps.
+func (psMonitor) ClaudeProcesses() []ProcessInfo {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	output, err := exec.CommandContext(ctx, "ps", "-axo", "pid=,%cpu=,rss=,args=").Output()
+	if err != nil {
+		return nil
+	}
+
+	var procs []ProcessInfo
+	for _, line := range strings.Split(string(output), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 4 || !isClaudeCommand(fields[3:]) {
+			continue
+		}
+		pid, err := strconv.Atoi(fields[0])
+		if err != nil {
+			continue
+		}
+		cpuPercent, _ := strconv.ParseFloat(fields[1], 64)
+		memoryKB, _ := strconv.Atoi(fields[2])
+		procs = append(procs, ProcessInfo{
+			PID:        pid,
+			Command:    strings.Join(fields[3:], " "),
+			CPUPercent: cpuPercent,
+			MemoryMB:   float64(memoryKB) / 1024.0,
+		})
+	}
+
+	if len(procs) > 0 {
+		cwds := lsofCwds(procs)
+		for i := range procs {
+			procs[i].Cwd = cwds[procs[i].PID]
+		}
+	}
+	return procs
+}
+
+// lsofCwds returns the working directories of the processes using lsof.
+func lsofCwds(procs []ProcessInfo) map[int]string {
+	pids := make([]string, len(procs))
+	for i, proc := range procs {
+		pids[i] = strconv.Itoa(proc.PID)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// -F pn prints "p<pid>" and "n<path>" lines
+	output, err := exec.CommandContext(ctx, "lsof", "-a", "-d", "cwd", "-F", "pn", "-p", strings.Join(pids, ",")).Output()
+	if err != nil && len(output) == 0 {
+		return nil
+	}
+
+	cwds := make(map[int]string)
+	pid := 0
+	for _, line := range strings.Split(string(output), "\n") {
+		if len(line) < 2 {
+			continue
+		}
+		switch line[0] {
+		case 'p':
+			pid, _ = strconv.Atoi(line[1:])
+		case 'n':
+			cwds[pid] = line[1:]
+		}
+	}
+	return cwds
+}
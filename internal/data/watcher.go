@@ -0,0 +1,136 @@
+package data
+
+import (
+	"path/filepath"
+	"time"
+)
+
+// WatchDebounce is how long a burst of file changes may settle before
+// the data is refreshed.
+const WatchDebounce = 250 * time.Millisecond
+
+// fileWatcher reports changes to files in watched directories.
+type fileWatcher interface {
+	// Add starts watching a directory (not recursively).
+	Add(dir string) error
+	// Events delivers the paths of changed files. An empty path means
+	// events were lost and everything should be refreshed. A watched
+	// directory is delivered when its watch is dropped, e.g. because it
+	// was deleted.
+	Events() <-chan string
+	// Close stops watching and closes the events channel.
+	Close() error
+}
+
+// Watch starts watching the Claude data directories and returns a channel
+// that receives fresh dashboard data shortly after any data file changes,
+// or once commits were read in the background. Only changed files are
+// re-parsed. An error means watching is unavailable
+// and the caller should poll instead.
+func (m *Manager) Watch() (<-chan DashboardData, error) {
+	w, err := newFileWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	watched := make(map[string]bool)
+	var addErr error
+	for _, root := range m.roots {
+		if err := w.Add(root); err != nil {
+			addErr = err
+			continue
+		}
+		watched[root] = true
+	}
+	if len(watched) == 0 {
+		w.Close()
+		return nil, addErr
+	}
+	m.addProjectWatches(w, watched)
+
+	m.mu.Lock()
+	if m.watcher != nil {
+		m.watcher.Close()
+	}
+	m.watcher = w
+	m.mu.Unlock()
+
+	updates := make(chan DashboardData, 1)
+	go m.watchLoop(w, watched, updates)
+	return updates, nil
+}
+
+// watchLoop refreshes data after changes settle. The updates channel
+// holds only the latest data, so a busy consumer never sees stale data.
+func (m *Manager) watchLoop(w fileWatcher, watched map[string]bool, updates chan DashboardData) {
+	defer close(updates)
+
+	var settle <-chan time.Time
+	for {
+		select {
+		case path, ok := <-w.Events():
+			if !ok {
+				return
+			}
+			// A directory whose watch was dropped is watched again if
+			// it comes back
+			delete(watched, path)
+			if m.isDataFile(path) && settle == nil {
+				settle = time.After(WatchDebounce)
+			}
+
+		case <-m.gitLoaded:
+			if settle == nil {
+				settle = time.After(WatchDebounce)
+			}
+
+		case <-settle:
+			settle = nil
+			m.addProjectWatches(w, watched)
+			dashData := m.GetDashboardData(true)
+
+			select {
+			case <-updates:
+			default:
+			}
+			updates <- dashData
+		}
+	}
+}
+
+// addProjectWatches watches the roots, their projects directories and any
+// project directories not yet watched. Failures are retried on the next
+// refresh.
+func (m *Manager) addProjectWatches(w fileWatcher, watched map[string]bool) {
+	for _, root := range m.roots {
+		projectsDir := filepath.Join(root, "projects")
+		dirs, _ := filepath.Glob(filepath.Join(projectsDir, "*"))
+		for _, dir := range append([]string{root, projectsDir}, dirs...) {
+			if watched[dir] {
+				continue
+			}
+			if err := w.Add(dir); err == nil {
+				watched[dir] = true
+			}
+		}
+	}
+}
+
+// isDataFile returns whether a change to path can affect parsed data.
+func (m *Manager) isDataFile(path string) bool {
+	if path == "" {
+		return true
+	}
+	dir, base := filepath.Split(path)
+	dir = filepath.Clean(dir)
+	for _, root := range m.roots {
+		switch dir {
+		case root:
+			return base == statsCacheName || base == "projects"
+		case filepath.Join(root, "projects"):
+			// A project directory was created or removed
+			return true
+		}
+	}
+	return filepath.Ext(base) == ".jsonl" || base == filepath.Base(sessionsIndexGlob)
+}
@@ -0,0 +1,108 @@
+//go:build linux
+
+package data
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"unsafe"
+)
+
+// inotifyMask selects the events that can change parsed data.
+const inotifyMask = syscall.IN_CREATE | syscall.IN_CLOSE_WRITE | syscall.IN_MODIFY |
+	syscall.IN_MOVED_TO | syscall.IN_MOVED_FROM | syscall.IN_DELETE
+
+// inotifyWatcher watches directories using Linux inotify.
+type inotifyWatcher struct {
+	fd     int
+	file   *os.File // Wraps fd so reads use the runtime poller
+	events chan string
+
+	mu      sync.Mutex
+	watches map[int32]string // Watch descriptor -> directory
+}
+
+// newFileWatcher creates an inotify-backed watcher.
+func newFileWatcher() (fileWatcher, error) {
+	fd, err := syscall.InotifyInit1(syscall.IN_NONBLOCK | syscall.IN_CLOEXEC)
+	if err != nil {
+		return nil, os.NewSyscallError("inotify_init1", err)
+	}
+
+	w := &inotifyWatcher{
+		fd:      fd,
+		file:    os.NewFile(uintptr(fd), "inotify"),
+		events:  make(chan string, 64),
+		watches: make(map[int32]string),
+	}
+	go w.readEvents()
+	return w, nil
+}
+
+// Add starts watching a directory.
+func (w *inotifyWatcher) Add(dir string) error {
+	wd, err := syscall.InotifyAddWatch(w.fd, dir, inotifyMask)
+	if err != nil {
+		return os.NewSyscallError("inotify_add_watch", err)
+	}
+
+	w.mu.Lock()
+	w.watches[int32(wd)] = dir
+	w.mu.Unlock()
+	return nil
+}
+
+// Events returns the channel of changed paths.
+func (w *inotifyWatcher) Events() <-chan string {
+	return w.events
+}
+
+// Close stops the watcher and closes the events channel.
+func (w *inotifyWatcher) Close() error {
+	return w.file.Close()
+}
+
+// readEvents decodes inotify events until the watcher is closed.
+func (w *inotifyWatcher) readEvents() {
+	defer close(w.events)
+
+	buf := make([]byte, 64*(syscall.SizeofInotifyEvent+syscall.NAME_MAX+1))
+	for {
+		n, err := w.file.Read(buf)
+		if err != nil {
+			return
+		}
+
+		for offset := 0; offset+syscall.SizeofInotifyEvent <= n; {
+			event := (*syscall.InotifyEvent)(unsafe.Pointer(&buf[offset]))
+			nameStart := offset + syscall.SizeofInotifyEvent
+			nameEnd := nameStart + int(event.Len)
+			offset = nameEnd
+			if nameEnd > n {
+				break
+			}
+			name := string(bytes.TrimRight(buf[nameStart:nameEnd], "\x00"))
+
+			w.mu.Lock()
+			dir, ok := w.watches[event.Wd]
+			if event.Mask&syscall.IN_IGNORED != 0 {
+				delete(w.watches, event.Wd)
+			}
+			w.mu.Unlock()
+
+			switch {
+			case event.Mask&syscall.IN_Q_OVERFLOW != 0:
+				// Events were lost; an empty path asks for a full refresh
+				w.events <- ""
+			case ok && event.Mask&syscall.IN_IGNORED != 0:
+				// The watch was dropped, so the directory can be added again
+				w.events <- dir
+			case ok && name != "":
+				w.events <- filepath.Join(dir, name)
+			}
+		}
+	}
+}
@@ -0,0 +1,46 @@
+//go:build linux
+
+package data
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/moshe-exe/lazyvibe/internal/config"
+)
+
+func TestWatchRecreatedProject(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	root := t.TempDir()
+	project := filepath.Join(root, "projects", "app")
+	if err := os.MkdirAll(project, 0o700); err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewManager(&config.Config{ClaudeDirs: []string{root}})
+	defer m.Close()
+	updates, err := m.Watch()
+	if err != nil {
+		t.Skipf("watching unavailable: %v", err)
+	}
+	// step makes a change and waits for the refresh it causes
+	step := func(what string, change func() error) {
+		t.Helper()
+		if err := change(); err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case <-updates:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("no refresh after %s", what)
+		}
+	}
+
+	step("deleting the project", func() error { return os.Remove(project) })
+	step("recreating the project", func() error { return os.Mkdir(project, 0o700) })
+	step("writing a transcript in it", func() error {
+		return os.WriteFile(filepath.Join(project, "s1.jsonl"), []byte("{}\n"), 0o600)
+	})
+}
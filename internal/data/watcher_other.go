@@ -0,0 +1,11 @@
+//go:build !linux
+
+package data
+
+import "errors"
+
+// newFileWatcher reports that watching is unsupported, so callers
+// fall back to polling.
+func newFileWatcher() (fileWatcher, error) {
+	return nil, errors.New("file watching is not supported on this platform")
+}
@@ -0,0 +1,103 @@
+package query
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Fuzzy scoring weights
+const (
+	scoreMatch       = 1
+	scoreConsecutive = 4 // Follows the previous matched character
+	scoreWordStart   = 3 // Starts a word
+	scoreFirstChar   = 2 // First character of the text
+)
+
+// fuzzyMatchAll matches every pattern against text and returns the summed
+// score and the union of matched rune positions.
+func fuzzyMatchAll(text string, patterns []string) (int, []int, bool) {
+	total := 0
+	seen := make(map[int]bool)
+	var positions []int
+	for _, pattern := range patterns {
+		score, matched, ok := FuzzyMatch(text, pattern)
+		if !ok {
+			return 0, nil, false
+		}
+		total += score
+		for _, p := range matched {
+			if !seen[p] {
+				seen[p] = true
+				positions = append(positions, p)
+			}
+		}
+	}
+	sort.Ints(positions)
+	return total, positions, true
+}
+
+// FuzzyMatch reports whether the characters of pattern appear in order in
+// text, ignoring case. The score favors consecutive characters and word
+// starts; positions are the rune offsets of the matched characters.
+//
+// Matching is greedy from the left, then each character is moved as far
+// right as the next one allows, which keeps runs of consecutive matches
+// together ("test" in "t_e_s_test" matches the final word).
+func FuzzyMatch(text, pattern string) (int, []int, bool) {
+	runes := []rune(strings.ToLower(text))
+	pat := []rune(strings.ToLower(pattern))
+	if len(pat) == 0 {
+		return 0, nil, true
+	}
+
+	// Forward pass: find where the match can end earliest
+	positions := make([]int, len(pat))
+	p := 0
+	for i := 0; i < len(runes) && p < len(pat); i++ {
+		if runes[i] == pat[p] {
+			positions[p] = i
+			p++
+		}
+	}
+	if p < len(pat) {
+		return 0, nil, false
+	}
+
+	// Backward pass: pull matches right towards the last one
+	end := positions[len(pat)-1]
+	p = len(pat) - 1
+	for i := end; i >= 0 && p >= 0; i-- {
+		if runes[i] == pat[p] {
+			positions[p] = i
+			p--
+		}
+	}
+
+	original := []rune(text)
+	score := 0
+	for i, pos := range positions {
+		score += scoreMatch
+		if i > 0 && positions[i-1] == pos-1 {
+			score += scoreConsecutive
+		}
+		if pos == 0 {
+			score += scoreFirstChar
+		}
+		if pos == 0 || isWordBoundary(original[pos-1], original[pos]) {
+			score += scoreWordStart
+		}
+	}
+	// Prefer tighter matches
+	score -= (positions[len(positions)-1] - positions[0] + 1 - len(positions)) / 4
+
+	return score, positions, true
+}
+
+// isWordBoundary reports whether cur starts a word after prev.
+func isWordBoundary(prev, cur rune) bool {
+	if !unicode.IsLetter(prev) && !unicode.IsDigit(prev) {
+		return true
+	}
+	return unicode.IsLower(prev) && unicode.IsUpper(cur)
+}
@@ -0,0 +1,324 @@
+// Package query implements the filter language of the list panels, e.g.
+// `project:lazy branch:feat/* msgs:>50 after:2026-09-01 -model:haiku fix`.
+//
+// A query is a list of space separated terms that must all match. A term
+// is either field:value or free text, and a leading - negates it. Free
+// text is matched fuzzily and ranks the results; quotes keep spaces in a
+// value, e.g. "fix tests" or project:"my app".
+package query
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FieldType is the kind of value a field holds.
+type FieldType int
+
+const (
+	FieldString FieldType = iota // Case-insensitive substring or glob (* and ?)
+	FieldNumber                  // Comparison such as >50, <=10 or 3
+	FieldTime                    // Dates for after: and before:
+)
+
+// Schema maps the field names a panel supports to their types.
+// Every schema supports after: and before:, which compare Fields.Time.
+type Schema map[string]FieldType
+
+// Fields describes one record to match a query against.
+type Fields struct {
+	Text    []string // Matched by free text, best match wins
+	Strings map[string][]string
+	Numbers map[string]float64
+	Time    time.Time // Zero if unknown, which never matches after: or before:
+}
+
+// Query is a parsed filter query.
+type Query struct {
+	terms []term
+}
+
+type term struct {
+	negate bool
+	field  string // Empty for free text
+	text   string // Lowercased free text
+
+	// Field matchers, set according to the field type
+	pattern *regexp.Regexp
+	op      string
+	number  float64
+	after   bool
+	date    time.Time
+}
+
+// Parse parses a query. An empty query matches everything.
+func Parse(input string, schema Schema) (*Query, error) {
+	tokens, err := tokenize(input)
+	if err != nil {
+		return nil, err
+	}
+
+	q := &Query{}
+	for _, tok := range tokens {
+		t := term{}
+		if strings.HasPrefix(tok, "-") && len(tok) > 1 {
+			t.negate = true
+			tok = tok[1:]
+		}
+
+		name, value, isField := strings.Cut(tok, ":")
+		name = strings.ToLower(name)
+		if !isField || !isFieldTerm(name, value) {
+			t.text = strings.ToLower(unquote(tok))
+			q.terms = append(q.terms, t)
+			continue
+		}
+
+		value = unquote(value)
+		if value == "" {
+			return nil, fmt.Errorf("%s: missing value", name)
+		}
+		t.field = name
+
+		typ, ok := schema[name]
+		if name == "after" || name == "before" {
+			typ, ok = FieldTime, true
+		}
+		if !ok {
+			return nil, fmt.Errorf("unknown field %q", name)
+		}
+
+		switch typ {
+		case FieldString:
+			t.pattern = globPattern(value)
+		case FieldNumber:
+			t.op, t.number, err = parseComparison(value)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %v", name, err)
+			}
+		case FieldTime:
+			t.after = name == "after"
+			t.date, err = time.ParseInLocation("2006-01-02", value, time.Local)
+			if err != nil {
+				return nil, fmt.Errorf("%s: expected YYYY-MM-DD, got %q", name, value)
+			}
+		}
+		q.terms = append(q.terms, t)
+	}
+	return q, nil
+}
+
+// isFieldTerm reports whether name:value can be a field term, so that free
+// text like "http://x" or "a:b:c" is not mistaken for one. Quotes keep
+// colons in a value, e.g. project:"a:b".
+func isFieldTerm(name, value string) bool {
+	if name == "" || strings.HasPrefix(value, "//") {
+		return false
+	}
+	if strings.Contains(value, ":") && !strings.HasPrefix(value, `"`) {
+		return false
+	}
+	for _, r := range name {
+		if r < 'a' || r > 'z' {
+			return false
+		}
+	}
+	return true
+}
+
+// tokenize splits a query on spaces outside double quotes.
+func tokenize(input string) ([]string, error) {
+	var tokens []string
+	var cur strings.Builder
+	inQuote := false
+	for _, r := range input {
+		switch {
+		case r == '"':
+			inQuote = !inQuote
+			cur.WriteRune(r)
+		case r == ' ' && !inQuote:
+			if cur.Len() > 0 {
+				tokens = append(tokens, cur.String())
+				cur.Reset()
+			}
+		default:
+			cur.WriteRune(r)
+		}
+	}
+	if inQuote {
+		return nil, fmt.Errorf("unclosed quote")
+	}
+	if cur.Len() > 0 {
+		tokens = append(tokens, cur.String())
+	}
+	return tokens, nil
+}
+
+// unquote removes double quotes from a value.
+func unquote(s string) string {
+	return strings.ReplaceAll(s, `"`, "")
+}
+
+// globPattern compiles a case-insensitive pattern that matches values
+// containing value, where * matches any run and ? any single character.
+func globPattern(value string) *regexp.Regexp {
+	var expr strings.Builder
+	expr.WriteString("(?i)")
+	anchored := strings.ContainsAny(value, "*?")
+	if anchored {
+		expr.WriteString("^")
+	}
+	for _, r := range value {
+		switch r {
+		case '*':
+			expr.WriteString(".*")
+		case '?':
+			expr.WriteString(".")
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	if anchored {
+		expr.WriteString("$")
+	}
+	return regexp.MustCompile(expr.String())
+}
+
+// parseComparison parses values such as >50, <=1.5k or 3.
+func parseComparison(value string) (string, float64, error) {
+	op := "="
+	for _, candidate := range []string{">=", "<=", ">", "<", "="} {
+		if strings.HasPrefix(value, candidate) {
+			op = candidate
+			value = value[len(candidate):]
+			break
+		}
+	}
+
+	multiplier := 1.0
+	switch {
+	case strings.HasSuffix(strings.ToLower(value), "k"):
+		multiplier = 1e3
+		value = value[:len(value)-1]
+	case strings.HasSuffix(strings.ToLower(value), "m"):
+		multiplier = 1e6
+		value = value[:len(value)-1]
+	}
+	n, err := strconv.ParseFloat(strings.TrimPrefix(value, "$"), 64)
+	if err != nil {
+		return "", 0, fmt.Errorf("expected a number, got %q", value)
+	}
+	return op, n * multiplier, nil
+}
+
+// IsEmpty returns whether the query has no terms.
+func (q *Query) IsEmpty() bool {
+	return q == nil || len(q.terms) == 0
+}
+
+// HasText returns whether the query ranks results by free text.
+func (q *Query) HasText() bool {
+	if q == nil {
+		return false
+	}
+	for _, t := range q.terms {
+		if t.field == "" && !t.negate {
+			return true
+		}
+	}
+	return false
+}
+
+// Result describes how a record matched a query.
+type Result struct {
+	Score     int   // Higher is better, 0 without free text
+	Text      int   // Index into Fields.Text of the highlighted text
+	Positions []int // Rune offsets of matched characters in that text
+}
+
+// Match reports whether the record matches every term of the query.
+func (q *Query) Match(f Fields) (Result, bool) {
+	var res Result
+	if q == nil {
+		return res, true
+	}
+
+	// Free text terms are scored against each text; the best text wins
+	var texts []string
+	for _, t := range q.terms {
+		if t.field == "" && !t.negate {
+			texts = append(texts, t.text)
+		}
+	}
+	if len(texts) > 0 {
+		best := -1
+		for i, text := range f.Text {
+			score, positions, ok := fuzzyMatchAll(text, texts)
+			if ok && score > best {
+				best = score
+				res = Result{Score: score, Text: i, Positions: positions}
+			}
+		}
+		if best < 0 {
+			return Result{}, false
+		}
+	}
+
+	for _, t := range q.terms {
+		if t.field == "" && !t.negate {
+			continue
+		}
+		if t.matches(f) == t.negate {
+			return Result{}, false
+		}
+	}
+	return res, true
+}
+
+// matches reports whether a term, ignoring negation, matches the record.
+func (t term) matches(f Fields) bool {
+	switch {
+	case t.field == "":
+		for _, text := range f.Text {
+			if strings.Contains(strings.ToLower(text), t.text) {
+				return true
+			}
+		}
+		return false
+	case t.pattern != nil:
+		for _, value := range f.Strings[t.field] {
+			if t.pattern.MatchString(value) {
+				return true
+			}
+		}
+		return false
+	case t.op != "":
+		n, ok := f.Numbers[t.field]
+		if !ok {
+			return false
+		}
+		switch t.op {
+		case ">":
+			return n > t.number
+		case ">=":
+			return n >= t.number
+		case "<":
+			return n < t.number
+		case "<=":
+			return n <= t.number
+		default:
+			return n == t.number
+		}
+	default:
+		if f.Time.IsZero() {
+			return false
+		}
+		if t.after {
+			return !f.Time.Before(t.date)
+		}
+		return f.Time.Before(t.date)
+	}
+}
@@ -0,0 +1,202 @@
+package query
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+var testSchema = Schema{
+	"project": FieldString,
+	"branch":  FieldString,
+	"msgs":    FieldNumber,
+	"cost":    FieldNumber,
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		in      string
+		wantErr bool
+	}{
+		{in: ""},
+		{in: "   "},
+		{in: "fix tests"},
+		{in: "project:lazy branch:feat/* msgs:>50 -cost:<=1.5k after:2026-09-01"},
+		{in: `project:"my app"`},
+		{in: "http://example.com"}, // Not a field name, so free text
+		{in: "a:b:c"},              // Likewise
+		{in: "Project:lazy"},       // Field names ignore case
+		{in: `project:"a:b"`},
+		{in: "model:haiku", wantErr: true},
+		{in: "project:", wantErr: true},
+		{in: `project:""`, wantErr: true},
+		{in: "msgs:lots", wantErr: true},
+		{in: "msgs:>", wantErr: true},
+		{in: "after:yesterday", wantErr: true},
+		{in: "before:2026-13-01", wantErr: true},
+		{in: `"unclosed`, wantErr: true},
+	}
+	for _, tt := range tests {
+		_, err := Parse(tt.in, testSchema)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Parse(%q) error = %v, want error %v", tt.in, err, tt.wantErr)
+		}
+	}
+}
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{"", nil},
+		{"  fix   tests ", []string{"fix", "tests"}},
+		{`project:"my app" -"a b"`, []string{`project:"my app"`, `-"a b"`}},
+	}
+	for _, tt := range tests {
+		got, err := tokenize(tt.in)
+		if err != nil {
+			t.Errorf("tokenize(%q) error: %v", tt.in, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("tokenize(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseComparison(t *testing.T) {
+	tests := []struct {
+		in     string
+		op     string
+		number float64
+	}{
+		{"3", "=", 3},
+		{"=3", "=", 3},
+		{">50", ">", 50},
+		{">=50", ">=", 50},
+		{"<1.5", "<", 1.5},
+		{"<=10", "<=", 10},
+		{">1.5k", ">", 1500},
+		{"<2M", "<", 2e6},
+		{">$5", ">", 5},
+	}
+	for _, tt := range tests {
+		op, number, err := parseComparison(tt.in)
+		if err != nil {
+			t.Errorf("parseComparison(%q) error: %v", tt.in, err)
+			continue
+		}
+		if op != tt.op || number != tt.number {
+			t.Errorf("parseComparison(%q) = %s %v, want %s %v", tt.in, op, number, tt.op, tt.number)
+		}
+	}
+}
+
+func TestMatch(t *testing.T) {
+	record := Fields{
+		Text:    []string{"Fix flaky tests in CI", "lazyvibe"},
+		Strings: map[string][]string{"project": {"lazyvibe"}, "branch": {"feat/search"}},
+		Numbers: map[string]float64{"msgs": 120, "cost": 2.5},
+		Time:    time.Date(2026, 9, 15, 12, 0, 0, 0, time.Local),
+	}
+
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{"", true},
+		{"flaky", true},
+		{"FLAKY ci", true},
+		{"fxtst", true}, // Fuzzy
+		{"deploy", false},
+		{"project:lazy", true},
+		{"project:LAZY", true},
+		{"project:vibe", true},
+		{"project:other", false},
+		{"-project:lazy", false},
+		{"-project:other", true},
+		{"branch:feat/*", true},
+		{"branch:feat", true},
+		{"branch:*search", true},
+		{"branch:fix/*", false},
+		{"branch:feat/searc?", true},
+		{"msgs:>100", true},
+		{"msgs:>120", false},
+		{"msgs:>=120", true},
+		{"msgs:120", true},
+		{"msgs:<0.1k", false},
+		{"cost:<=2.5", true},
+		{"-cost:>1", false},
+		{"after:2026-09-15", true},
+		{"after:2026-09-16", false},
+		{"before:2026-09-16", true},
+		{"before:2026-09-15", false},
+		{"project:lazy msgs:>50 flaky", true},
+		{"project:lazy msgs:>500 flaky", false},
+		{"-flaky", false},
+		{"-deploy", true},
+	}
+	for _, tt := range tests {
+		q, err := Parse(tt.query, testSchema)
+		if err != nil {
+			t.Errorf("Parse(%q) error: %v", tt.query, err)
+			continue
+		}
+		if _, got := q.Match(record); got != tt.want {
+			t.Errorf("Parse(%q).Match() = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestMatchUnknownValues(t *testing.T) {
+	// A record without a number or time never matches comparisons on it
+	record := Fields{Text: []string{"session"}}
+	for _, query := range []string{"msgs:>0", "msgs:<1", "after:2000-01-01", "before:2100-01-01"} {
+		q, err := Parse(query, testSchema)
+		if err != nil {
+			t.Fatalf("Parse(%q) error: %v", query, err)
+		}
+		if _, ok := q.Match(record); ok {
+			t.Errorf("Parse(%q) matched a record without the value", query)
+		}
+	}
+}
+
+func TestMatchRanksBestText(t *testing.T) {
+	q, err := Parse("vibe", testSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, ok := q.Match(Fields{Text: []string{"very interesting bug", "lazyvibe"}})
+	if !ok {
+		t.Fatal("no match")
+	}
+	if res.Text != 1 || !reflect.DeepEqual(res.Positions, []int{4, 5, 6, 7}) {
+		t.Errorf("Match() = text %d at %v, want the consecutive match in text 1 at [4 5 6 7]", res.Text, res.Positions)
+	}
+}
+
+func TestIsEmptyAndHasText(t *testing.T) {
+	tests := []struct {
+		query   string
+		empty   bool
+		hasText bool
+	}{
+		{"", true, false},
+		{"fix", false, true},
+		{"-fix", false, false},
+		{"project:lazy", false, false},
+		{"project:lazy fix", false, true},
+	}
+	for _, tt := range tests {
+		q, err := Parse(tt.query, testSchema)
+		if err != nil {
+			t.Fatalf("Parse(%q) error: %v", tt.query, err)
+		}
+		if q.IsEmpty() != tt.empty || q.HasText() != tt.hasText {
+			t.Errorf("Parse(%q): IsEmpty() = %v, HasText() = %v, want %v, %v",
+				tt.query, q.IsEmpty(), q.HasText(), tt.empty, tt.hasText)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package report
+
+import (
+	"html/template"
+	"io"
+	"strings"
+)
+
+// htmlTemplate is a self-contained page: styles are inline and nothing is
+// loaded from elsewhere.
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"number":   formatNumber,
+	"cost":     formatCost,
+	"duration": durationText,
+	"weekday":  weekdayDate,
+	"summary":  summaryText,
+	"period":   periodText,
+	"changeClass": func(change string) string {
+		switch {
+		case strings.HasPrefix(change, "▲"):
+			return "up"
+		case strings.HasPrefix(change, "▼"):
+			return "down"
+		}
+		return ""
+	},
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; color: #1f2328; max-width: 960px; margin: 2em auto; padding: 0 1em; }
+h1 { font-size: 1.6em; }
+h2 { font-size: 1.2em; margin-top: 2em; border-bottom: 1px solid #d0d7de; padding-bottom: .3em; }
+table { border-collapse: collapse; }
+th, td { padding: .35em .8em; border-bottom: 1px solid #eaeef2; text-align: left; }
+th { font-weight: 600; }
+td.num, th.num { text-align: right; font-variant-numeric: tabular-nums; }
+.up { color: #1a7f37; }
+.down { color: #cf222e; }
+.muted { color: #656d76; }
+table.heatmap td, table.heatmap th { padding: 0; border: 0; font-size: .75em; font-weight: normal; }
+table.heatmap th { padding-right: .6em; color: #656d76; }
+table.heatmap td.day { width: 14px; height: 14px; border: 2px solid #fff; border-radius: 3px; }
+table.heatmap td.in { outline: 1px solid #656d76; outline-offset: -1px; }
+.l0 { background: #ebedf0; }
+.l1 { background: #9be9a8; }
+.l2 { background: #40c463; }
+.l3 { background: #30a14e; }
+.l4 { background: #216e39; }
+.future { background: none; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p class="muted">Compared with the previous {{.Kind}}, {{period .Previous}}.</p>
+
+<h2>Summary</h2>
+<table>
+<tr><th>Metric</th><th class="num">This {{.Kind}}</th><th class="num">Previous {{.Kind}}</th><th class="num">Change</th></tr>
+{{- range .Summary}}
+<tr><td>{{.Metric}}</td><td class="num">{{.Current}}</td><td class="num">{{.Previous}}</td><td class="num {{changeClass .Change}}">{{.Change}}</td></tr>
+{{- end}}
+</table>
+
+<h2>Top projects</h2>
+{{- if .Projects}}
+<table>
+<tr><th>Project</th><th class="num">Sessions</th><th class="num">Messages</th><th class="num">Tokens</th><th class="num">Cost</th><th class="num">Time</th></tr>
+{{- range .Projects}}
+<tr><td title="{{.ProjectPath}}">{{.ProjectName}}</td><td class="num">{{number .SessionCount}}</td><td class="num">{{number .TotalMessages}}</td><td class="num">{{number .Tokens.Total}}</td><td class="num">{{cost .TotalCost}}</td><td class="num">{{duration .TotalDuration}}</td></tr>
+{{- end}}
+</table>
+{{- else}}
+<p class="muted">No sessions in this period.</p>
+{{- end}}
+
+<h2>Longest sessions</h2>
+{{- if .Longest}}
+<table>
+<tr><th>Started</th><th>Project</th><th class="num">Duration</th><th class="num">Messages</th><th>Summary</th></tr>
+{{- range .Longest}}
+<tr><td>{{.Created.Local.Format "2006-01-02 15:04"}}</td><td>{{.ProjectName}}</td><td class="num">{{.FormatDuration}}</td><td class="num">{{number .MessageCount}}</td><td>{{summary .Summary}}</td></tr>
+{{- end}}
+</table>
+{{- else}}
+<p class="muted">No sessions with known times in this period.</p>
+{{- end}}
+
+<h2>Busiest days</h2>
+{{- if .Busiest}}
+<table>
+<tr><th>Date</th><th class="num">Sessions</th><th class="num">Messages</th><th class="num">Tokens</th><th class="num">Cost</th></tr>
+{{- range .Busiest}}
+<tr><td>{{weekday .Date}}</td><td class="num">{{number .SessionCount}}</td><td class="num">{{number .MessageCount}}</td><td class="num">{{number .TokenCount}}</td><td class="num">{{cost .Cost}}</td></tr>
+{{- end}}
+</table>
+{{- else}}
+<p class="muted">No activity in this period.</p>
+{{- end}}
+
+<h2>Activity</h2>
+<p class="muted">Messages per day over the last {{len .Heatmap}} weeks; outlined days are part of this report.</p>
+<table class="heatmap">
+<tr><th></th>{{range .Weekdays}}<th>{{.}}</th>{{end}}</tr>
+{{- range .Heatmap}}
+<tr><th>{{.Start.Format "Jan _2"}}</th>
+{{- range .Days}}<td class="day {{if .Future}}future{{else}}l{{.Level}}{{end}}{{if .InPeriod}} in{{end}}" title="{{.Date}}: {{number .Messages}} messages"></td>{{end}}</tr>
+{{- end}}
+</table>
+</body>
+</html>
+`))
+
+// WriteHTML writes the report as a self-contained HTML page.
+func WriteHTML(w io.Writer, r Report) error {
+	return htmlTemplate.Execute(w, struct {
+		Report
+		Summary  []summaryRow
+		Weekdays []string
+	}{r, r.summary(), r.weekdays()})
+}
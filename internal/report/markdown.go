@@ -0,0 +1,107 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// heatmapGlyphs draws heatmap levels 0-4 in text.
+var heatmapGlyphs = []string{"·", "░", "▒", "▓", "█"}
+
+// WriteMarkdown writes the report as a Markdown document.
+func WriteMarkdown(w io.Writer, r Report) error {
+	var b strings.Builder
+	unit := r.Kind.String()
+
+	fmt.Fprintf(&b, "# %s\n\n", r.Title())
+	fmt.Fprintf(&b, "Compared with the previous %s, %s.\n\n", unit, periodText(r.Previous))
+
+	b.WriteString("## Summary\n\n")
+	table(&b, []string{"Metric", "This " + unit, "Previous " + unit, "Change"}, "-rrr")
+	for _, row := range r.summary() {
+		tableRow(&b, row.Metric, row.Current, row.Previous, row.Change)
+	}
+
+	b.WriteString("\n## Top projects\n\n")
+	if len(r.Projects) == 0 {
+		b.WriteString("No sessions in this period.\n")
+	} else {
+		table(&b, []string{"Project", "Sessions", "Messages", "Tokens", "Cost", "Time"}, "-rrrrr")
+		for _, p := range r.Projects {
+			tableRow(&b, p.ProjectName, formatNumber(p.SessionCount), formatNumber(p.TotalMessages),
+				formatNumber(p.Tokens.Total()), formatCost(p.TotalCost), durationText(p.TotalDuration))
+		}
+	}
+
+	b.WriteString("\n## Longest sessions\n\n")
+	if len(r.Longest) == 0 {
+		b.WriteString("No sessions with known times in this period.\n")
+	} else {
+		table(&b, []string{"Started", "Project", "Duration", "Messages", "Summary"}, "--rr-")
+		for _, s := range r.Longest {
+			tableRow(&b, s.Created.Local().Format("2006-01-02 15:04"), s.ProjectName, s.FormatDuration(),
+				formatNumber(s.MessageCount), summaryText(s.Summary))
+		}
+	}
+
+	b.WriteString("\n## Busiest days\n\n")
+	if len(r.Busiest) == 0 {
+		b.WriteString("No activity in this period.\n")
+	} else {
+		table(&b, []string{"Date", "Sessions", "Messages", "Tokens", "Cost"}, "-rrrr")
+		for _, day := range r.Busiest {
+			tableRow(&b, weekdayDate(day.Date), formatNumber(day.SessionCount), formatNumber(day.MessageCount),
+				formatNumber(day.TokenCount), formatCost(day.Cost))
+		}
+	}
+
+	b.WriteString("\n## Activity\n\n")
+	fmt.Fprintf(&b, "Messages per day over the last %d weeks; ◀ marks the weeks of this report.\n\n", len(r.Heatmap))
+	b.WriteString("```text\n")
+	b.WriteString("        " + strings.Join(r.weekdays(), " ") + "\n")
+	for _, week := range r.Heatmap {
+		b.WriteString(week.Start.Format("Jan _2") + " ")
+		inPeriod := false
+		for _, day := range week.Days {
+			glyph := heatmapGlyphs[day.Level]
+			if day.Future {
+				glyph = " "
+			}
+			b.WriteString(" " + glyph + glyph)
+			inPeriod = inPeriod || day.InPeriod
+		}
+		if inPeriod {
+			b.WriteString(" ◀")
+		}
+		b.WriteString("\n")
+	}
+	fmt.Fprintf(&b, "\nless %s more\n", strings.Join(heatmapGlyphs, " "))
+	b.WriteString("```\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// table writes a Markdown table header. align holds a character per
+// column: r to align it right, anything else to align it left.
+func table(b *strings.Builder, headers []string, align string) {
+	tableRow(b, headers...)
+	rule := make([]string, len(headers))
+	for i := range rule {
+		rule[i] = "---"
+		if align[i] == 'r' {
+			rule[i] = "--:"
+		}
+	}
+	fmt.Fprintf(b, "|%s|\n", strings.Join(rule, "|"))
+}
+
+// tableRow writes a Markdown table row, escaping pipes in cells.
+func tableRow(b *strings.Builder, cells ...string) {
+	escaped := make([]string, len(cells))
+	for i, cell := range cells {
+		escaped[i] = strings.ReplaceAll(cell, "|", `\|`)
+	}
+	fmt.Fprintf(b, "| %s |\n", strings.Join(escaped, " | "))
+}
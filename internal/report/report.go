@@ -0,0 +1,364 @@
+// Package report builds weekly and monthly usage reports from dashboard
+// data and writes them as Markdown or self-contained HTML. Reports hold no
+// generation time and sort with tiebreakers, so the same data always gives
+// the same output.
+package report
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/moshe-exe/lazyvibe/internal/data"
+)
+
+// Kind is the length of a report's period.
+type Kind int
+
+const (
+	Week Kind = iota
+	Month
+)
+
+// String returns the name of the kind as given on the command line.
+func (k Kind) String() string {
+	if k == Month {
+		return "month"
+	}
+	return "week"
+}
+
+// ParseKind returns the kind with the given name, ignoring case.
+func ParseKind(name string) (Kind, error) {
+	switch strings.ToLower(name) {
+	case "week":
+		return Week, nil
+	case "month":
+		return Month, nil
+	}
+	return 0, fmt.Errorf("unknown period %q (want week or month)", name)
+}
+
+// DefaultStart returns where a report of the kind covering now starts:
+// the first day of the week, or the first of the month.
+func DefaultStart(kind Kind, now time.Time) time.Time {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	if kind == Month {
+		return today.AddDate(0, 0, 1-today.Day())
+	}
+	return data.StartOfWeek(today)
+}
+
+// Sizes of the report's lists.
+const (
+	topProjects     = 10
+	longestSessions = 10
+	busiestDays     = 5
+	heatmapWeeks    = 12
+	maxSummaryLen   = 60 // Characters of a session summary shown
+)
+
+// Report is a summary of the usage in a period, compared with the period
+// of the same length just before it.
+type Report struct {
+	Kind     Kind
+	Period   data.Period
+	Previous data.Period
+
+	Totals         Totals
+	PreviousTotals Totals
+
+	Projects []data.ProjectSummary // Most active projects in the period
+	Longest  []data.SessionEntry   // Longest sessions in the period
+	Busiest  []data.DailyActivity  // Days with the most messages
+	Heatmap  []HeatmapWeek         // Weeks up to the period's end, oldest first
+}
+
+// Totals sums the sessions and daily activity of a period.
+type Totals struct {
+	Sessions int
+	Messages int
+	Tokens   int           // From daily activity
+	Cost     float64       // From daily activity
+	Duration time.Duration // Summed over sessions with known times
+	Projects int
+	Commits  int
+}
+
+// HeatmapWeek is a row of the heatmap, from the first day of the week.
+type HeatmapWeek struct {
+	Start time.Time
+	Days  [7]HeatmapDay
+}
+
+// HeatmapDay is a cell of the heatmap.
+type HeatmapDay struct {
+	Date     string
+	Messages int
+	Level    int  // 0 for no activity up to 4 for the busiest days
+	InPeriod bool // Whether the day is part of the report's period
+	Future   bool // Whether the day is after the period
+}
+
+// Build makes the report of the kind starting on the day of from. As in
+// the dashboard, sessions belong to the period of their last activity,
+// and tokens and cost to the day they were used.
+func Build(d *data.DashboardData, kind Kind, from time.Time) Report {
+	start := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, from.Location())
+	r := Report{Kind: kind}
+	if kind == Month {
+		r.Period = data.Period{Start: start, End: start.AddDate(0, 1, 0)}
+		r.Previous = data.Period{Start: start.AddDate(0, -1, 0), End: start}
+	} else {
+		r.Period = data.Period{Start: start, End: start.AddDate(0, 0, 7)}
+		r.Previous = data.Period{Start: start.AddDate(0, 0, -7), End: start}
+	}
+
+	sessions := d.SessionsIn(r.Period)
+	projects := data.AggregateProjects(sessions)
+	days := append([]data.DailyActivity(nil), d.DailyActivityIn(r.Period)...)
+	r.Totals = totals(sessions, projects, days)
+	previous := d.SessionsIn(r.Previous)
+	r.PreviousTotals = totals(previous, data.AggregateProjects(previous), d.DailyActivityIn(r.Previous))
+
+	sort.SliceStable(projects, func(i, j int) bool {
+		a, b := projects[i], projects[j]
+		if a.TotalMessages != b.TotalMessages {
+			return a.TotalMessages > b.TotalMessages
+		}
+		if a.SessionCount != b.SessionCount {
+			return a.SessionCount > b.SessionCount
+		}
+		return a.ProjectPath < b.ProjectPath
+	})
+	r.Projects = first(projects, topProjects)
+
+	var timed []data.SessionEntry
+	for _, s := range sessions {
+		if s.TimesKnown() {
+			timed = append(timed, s)
+		}
+	}
+	sort.SliceStable(timed, func(i, j int) bool {
+		a, b := timed[i], timed[j]
+		if a.Duration() != b.Duration() {
+			return a.Duration() > b.Duration()
+		}
+		if !a.Created.Equal(b.Created) {
+			return a.Created.Before(b.Created)
+		}
+		return a.SessionID < b.SessionID
+	})
+	r.Longest = first(timed, longestSessions)
+
+	sort.SliceStable(days, func(i, j int) bool {
+		if days[i].MessageCount != days[j].MessageCount {
+			return days[i].MessageCount > days[j].MessageCount
+		}
+		return days[i].Date < days[j].Date
+	})
+	r.Busiest = first(days, busiestDays)
+
+	r.Heatmap = heatmap(d.DailyActivity, r.Period)
+	return r
+}
+
+// Title names the report, e.g. "Weekly report: 2026-10-05 to 2026-10-11".
+func (r Report) Title() string {
+	name := "Weekly"
+	if r.Kind == Month {
+		name = "Monthly"
+	}
+	return fmt.Sprintf("%s report: %s", name, periodText(r.Period))
+}
+
+// totals sums sessions, the projects aggregated from them and the days of
+// their period.
+func totals(sessions []data.SessionEntry, projects []data.ProjectSummary, days []data.DailyActivity) Totals {
+	t := Totals{Sessions: len(sessions), Projects: len(projects)}
+	for _, s := range sessions {
+		t.Messages += s.MessageCount
+		t.Duration += s.Duration()
+	}
+	for _, day := range days {
+		t.Tokens += day.TokenCount
+		t.Cost += day.Cost
+	}
+	for _, p := range projects {
+		t.Commits += p.Commits
+	}
+	return t
+}
+
+// heatmap lays out messages per day over the weeks ending with the week
+// of the period's last day. Levels are relative to the busiest day shown.
+func heatmap(daily []data.DailyActivity, p data.Period) []HeatmapWeek {
+	messages := make(map[string]int, len(daily))
+	for _, day := range daily {
+		messages[day.Date] = day.MessageCount
+	}
+
+	last := p.End.AddDate(0, 0, -1)
+	lastWeek := data.StartOfWeek(last)
+	weeks := make([]HeatmapWeek, heatmapWeeks)
+	maxMessages := 0
+	for w := range weeks {
+		start := lastWeek.AddDate(0, 0, 7*(w-heatmapWeeks+1))
+		weeks[w].Start = start
+		for i := range weeks[w].Days {
+			date := start.AddDate(0, 0, i)
+			day := HeatmapDay{
+				Date:     date.Format("2006-01-02"),
+				InPeriod: p.Contains(date),
+				Future:   !date.Before(p.End),
+			}
+			if !day.Future {
+				day.Messages = messages[day.Date]
+				maxMessages = max(maxMessages, day.Messages)
+			}
+			weeks[w].Days[i] = day
+		}
+	}
+
+	for w := range weeks {
+		for i := range weeks[w].Days {
+			weeks[w].Days[i].Level = level(weeks[w].Days[i].Messages, maxMessages)
+		}
+	}
+	return weeks
+}
+
+// level buckets a count relative to the maximum into 0-4.
+func level(n, maxN int) int {
+	if n == 0 || maxN == 0 {
+		return 0
+	}
+	ratio := float64(n) / float64(maxN)
+	switch {
+	case ratio < 0.25:
+		return 1
+	case ratio < 0.5:
+		return 2
+	case ratio < 0.75:
+		return 3
+	}
+	return 4
+}
+
+// weekdays returns the two-letter names of the heatmap's columns.
+func (r Report) weekdays() []string {
+	names := make([]string, 7)
+	for i := range names {
+		names[i] = r.Heatmap[0].Start.AddDate(0, 0, i).Weekday().String()[:2]
+	}
+	return names
+}
+
+// first returns at most the first n items.
+func first[T any](items []T, n int) []T {
+	if len(items) > n {
+		return items[:n]
+	}
+	return items
+}
+
+// periodText describes a period by its first and last day.
+func periodText(p data.Period) string {
+	return p.Start.Format("2006-01-02") + " to " + p.End.AddDate(0, 0, -1).Format("2006-01-02")
+}
+
+// change describes how a value moved from the previous period, e.g.
+// "▲ 25%", "▼ 10%", "new" when it was zero, or "–" when both are zero.
+func change(current, previous float64) string {
+	switch {
+	case previous == 0 && current == 0:
+		return "–"
+	case previous == 0:
+		return "new"
+	}
+	pct := (current - previous) / previous * 100
+	switch text := fmt.Sprintf("%.0f%%", abs(pct)); {
+	case text == "0%":
+		return "0%"
+	case pct > 0:
+		return "▲ " + text
+	default:
+		return "▼ " + text
+	}
+}
+
+func abs(f float64) float64 {
+	if f < 0 {
+		return -f
+	}
+	return f
+}
+
+// formatNumber formats a number with comma separators.
+func formatNumber(n int) string {
+	if n < 0 {
+		return "-" + formatNumber(-n)
+	}
+	s := fmt.Sprintf("%d", n)
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	return s
+}
+
+// formatCost formats a USD amount.
+func formatCost(cost float64) string {
+	return fmt.Sprintf("$%.2f", cost)
+}
+
+// summaryRow is a line of the summary table.
+type summaryRow struct {
+	Metric, Current, Previous, Change string
+}
+
+// summary returns the rows of the summary table.
+func (r Report) summary() []summaryRow {
+	cur, prev := r.Totals, r.PreviousTotals
+	count := func(metric string, c, p int) summaryRow {
+		return summaryRow{metric, formatNumber(c), formatNumber(p), change(float64(c), float64(p))}
+	}
+	return []summaryRow{
+		count("Sessions", cur.Sessions, prev.Sessions),
+		count("Messages", cur.Messages, prev.Messages),
+		count("Tokens", cur.Tokens, prev.Tokens),
+		{"Cost", formatCost(cur.Cost), formatCost(prev.Cost), change(cur.Cost, prev.Cost)},
+		{"Time in sessions", data.FormatDuration(cur.Duration), data.FormatDuration(prev.Duration),
+			change(cur.Duration.Seconds(), prev.Duration.Seconds())},
+		count("Projects", cur.Projects, prev.Projects),
+		count("Commits", cur.Commits, prev.Commits),
+	}
+}
+
+// durationText formats a summed duration, "—" if none is known.
+func durationText(d time.Duration) string {
+	if d == 0 {
+		return "—"
+	}
+	return data.FormatDuration(d)
+}
+
+// weekdayDate formats a date (2006-01-02) with its weekday, e.g.
+// "Mon 2026-10-05".
+func weekdayDate(date string) string {
+	t, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return date
+	}
+	return t.Format("Mon 2006-01-02")
+}
+
+// summaryText puts a session summary on one line, cut to maxSummaryLen.
+func summaryText(summary string) string {
+	summary = strings.Join(strings.Fields(summary), " ")
+	runes := []rune(summary)
+	if len(runes) > maxSummaryLen {
+		return string(runes[:maxSummaryLen-1]) + "…"
+	}
+	return summary
+}
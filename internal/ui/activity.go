@@ -17,6 +17,7 @@ const (
 	MetricSessions
 	MetricTools
 	MetricTokens
+	MetricCost
 )
 
 // Name returns the display name for the metric.
@@ -30,10 +31,38 @@ func (m HeatmapMetric) Name() string {
 		return "Tools"
 	case MetricTokens:
 		return "Tokens"
+	case MetricCost:
+		return "Cost"
 	}
 	return "Messages"
 }
 
+// value returns the metric's value for a day. Cost is in cents.
+func (m HeatmapMetric) value(day data.DailyActivity) int {
+	switch m {
+	case MetricSessions:
+		return day.SessionCount
+	case MetricTools:
+		return day.ToolCallCount
+	case MetricTokens:
+		return day.TokenCount
+	case MetricCost:
+		return int(day.Cost*100 + 0.5)
+	}
+	return day.MessageCount
+}
+
+// format formats a metric value for display.
+func (m HeatmapMetric) format(v int) string {
+	switch m {
+	case MetricTokens:
+		return formatTokens(v)
+	case MetricCost:
+		return formatCost(float64(v) / 100)
+	}
+	return formatNumber(v)
+}
+
 // ActivityModel represents the activity heatmap panel.
 type ActivityModel struct {
 	data          *data.DashboardData
@@ -68,7 +97,7 @@ func (a *ActivityModel) SetSize(width, height int) {
 
 // CycleMetric cycles through heatmap metrics.
 func (a *ActivityModel) CycleMetric() {
-	a.heatmapMetric = (a.heatmapMetric + 1) % 4
+	a.heatmapMetric = (a.heatmapMetric + 1) % 5
 }
 
 // View renders the activity panel.
@@ -120,17 +149,7 @@ func (a ActivityModel) getHeatmapData() (map[string]int, int) {
 	activityMap := make(map[string]int)
 	maxVal := 0
 	for _, day := range filteredActivity {
-		var val int
-		switch a.heatmapMetric {
-		case MetricMessages:
-			val = day.MessageCount
-		case MetricSessions:
-			val = day.SessionCount
-		case MetricTools:
-			val = day.ToolCallCount
-		case MetricTokens:
-			val = day.TokenCount
-		}
+		val := a.heatmapMetric.value(day)
 		activityMap[day.Date] = val
 		if val > maxVal {
 			maxVal = val
@@ -149,17 +168,7 @@ func (a ActivityModel) renderHeatmapSummary() string {
 	total := 0
 	maxVal := 0
 	for _, day := range filteredActivity {
-		var val int
-		switch a.heatmapMetric {
-		case MetricMessages:
-			val = day.MessageCount
-		case MetricSessions:
-			val = day.SessionCount
-		case MetricTools:
-			val = day.ToolCallCount
-		case MetricTokens:
-			val = day.TokenCount
-		}
+		val := a.heatmapMetric.value(day)
 		total += val
 		if val > maxVal {
 			maxVal = val
@@ -172,12 +181,13 @@ func (a ActivityModel) renderHeatmapSummary() string {
 	}
 
 	// 5-char margin to align with heatmap (month label column)
-	return "     " + MutedStyle.Render(fmt.Sprintf("%s · %d/day · %s max",
-		formatNumber(total), avg, formatNumber(maxVal)))
+	m := a.heatmapMetric
+	return "     " + MutedStyle.Render(fmt.Sprintf("%s · %s/day · %s max",
+		m.format(total), m.format(avg), m.format(maxVal)))
 }
 
 // renderHeatmap renders a GitHub-style activity heatmap with month labels.
-// Shows weeks as rows, days as columns (Mon-Sun).
+// Shows weeks as rows, days as columns from the first day of the week.
 func (a ActivityModel) renderHeatmap() []string {
 	if a.data == nil || len(a.data.DailyActivity) == 0 {
 		return []string{MutedStyle.Render("No activity data")}
@@ -196,29 +206,42 @@ func (a ActivityModel) renderHeatmap() []string {
 		weeksToShow = 4
 	}
 
-	// Generate dates for the last N weeks
+	return heatmapGrid(activityMap, maxVal, weeksToShow, a.heatmapEnd())
+}
+
+// heatmapEnd returns the last day the heatmap shows: today, or the end of
+// a time range that ended earlier.
+func (a ActivityModel) heatmapEnd() time.Time {
 	now := time.Now()
-	// Find the most recent Saturday to align weeks
-	daysUntilSunday := int(now.Weekday())
-	endDate := now.AddDate(0, 0, -daysUntilSunday+6) // End on Saturday
-	startDate := endDate.AddDate(0, 0, -(weeksToShow*7 - 1))
+	p := a.timeRange.Period()
+	if !p.End.IsZero() && p.End.Before(now) {
+		return p.End.AddDate(0, 0, -1)
+	}
+	return now
+}
+
+// heatmapGrid renders the weeks of activity up to the week containing
+// end as a grid with one row per week, most recent first, and one column
+// per weekday starting on the configured first day of the week.
+func heatmapGrid(activityMap map[string]int, maxVal, weeksToShow int, end time.Time) []string {
+	startDate := data.StartOfWeek(end).AddDate(0, 0, -(weeksToShow-1)*7)
 
 	// Use consistent block character, vary color for intensity
 	block := "█"
 
 	// Day column headers (with 5-char margin for month labels)
-	dayLabels := []string{"Mo", "Tu", "We", "Th", "Fr", "Sa", "Su"}
 	var lines []string
 
 	// Header row with day labels
 	var headerRow strings.Builder
 	headerRow.WriteString("     ") // 5-char margin for month labels
-	for _, label := range dayLabels {
+	for i := 0; i < 7; i++ {
+		label := startDate.AddDate(0, 0, i).Weekday().String()[:2]
 		headerRow.WriteString(MutedStyle.Render(label + " "))
 	}
 	lines = append(lines, headerRow.String())
 
-	// Build the grid: N rows (weeks), 7 columns (Mon-Sun)
+	// Build the grid: N rows (weeks), 7 columns (days of the week)
 	// Most recent week at top, oldest at bottom
 	prevMonth := ""
 	for week := weeksToShow - 1; week >= 0; week-- {
@@ -283,6 +306,11 @@ func (a ActivityModel) renderHeatmap() []string {
 
 // renderLegend renders the color legend for the heatmap.
 func (a ActivityModel) renderLegend() string {
+	return heatmapLegend()
+}
+
+// heatmapLegend renders the color legend for a heatmap grid.
+func heatmapLegend() string {
 	block := "██"
 	none := lipgloss.NewStyle().Foreground(SurfaceDark).Render(block)
 	low := lipgloss.NewStyle().Foreground(TextMuted).Render(block)
@@ -1,12 +1,14 @@
 package ui
 
 import (
-	"os/exec"
+	"errors"
 	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/moshe-exe/lazyvibe/internal/clipboard"
+	"github.com/moshe-exe/lazyvibe/internal/config"
 	"github.com/moshe-exe/lazyvibe/internal/data"
 )
 
@@ -16,12 +18,16 @@ const (
 	PanelActivity
 	PanelProjects
 	PanelSessions
-	panelCount = 4
+	PanelTools
+	PanelFiles
+	panelCount = 6
 )
 
 // Panel grid layout for vim navigation
 // Left: Stats (top), Activity (bottom)
-// Right: Projects (top), Sessions (bottom)
+// Right: Projects, Tools or Files (top), Sessions (bottom)
+// The top-right cell holds whichever of Projects, Tools and Files was
+// focused last.
 var panelGrid = [][]int{
 	{PanelStats, PanelProjects},
 	{PanelActivity, PanelSessions},
@@ -30,6 +36,33 @@ var panelGrid = [][]int{
 // Messages for timer-based updates
 type vmTickMsg struct{}
 type sessionsTickMsg struct{}
+type pulseTickMsg struct{}
+
+// watchStartedMsg reports whether file watching could be started.
+type watchStartedMsg struct {
+	updates <-chan data.DashboardData
+	err     error
+}
+
+// watchUpdateMsg carries data refreshed after a file change.
+type watchUpdateMsg struct {
+	data data.DashboardData
+}
+
+// searchResultsMsg carries the results of a full-text search.
+type searchResultsMsg struct {
+	seq     int
+	results []data.SearchResult
+}
+
+// location is a place in the dashboard that Esc returns to.
+type location struct {
+	focused        int
+	topRight       int
+	project        string // Path of the project shown full screen, if any
+	session        string // Session selected in the project view
+	sessionsFilter string // Query applied to the Sessions panel
+}
 
 // Model is the main application model.
 type Model struct {
@@ -40,6 +73,7 @@ type Model struct {
 	height int
 
 	focused   int
+	topRight  int // Panel shown in the top-right cell
 	paused    bool
 	showHelp  bool
 	timeRange data.TimeRange
@@ -48,32 +82,86 @@ type Model struct {
 	flashMessage string
 	flashExpiry  time.Time
 
-	// Sub-models
-	header   HeaderModel
-	stats    StatsModel
-	activity ActivityModel
-	projects ProjectsModel
-	sessions SessionsModel
-	help     HelpModel
-	detail   DetailModal
+	// Places left by drilling down, most recent last
+	backStack []location
 
-	// Tickers
-	vmTicker       *time.Ticker
-	sessionsTicker *time.Ticker
+	// Sub-models
+	header      HeaderModel
+	stats       StatsModel
+	activity    ActivityModel
+	projects    ProjectsModel
+	sessions    SessionsModel
+	tools       ToolsModel
+	files       FilesModel
+	help        HelpModel
+	detail      DetailModal
+	transcript  TranscriptModal
+	diagnostics DiagnosticsModal
+	search      SearchModal
+	yank        YankMenu
+	ranges      RangePicker
+	project     ProjectView
+
+	// Commands run for the selected session
+	resumeCommand string
+	editorCommand string
+
+	clipboard *clipboard.Clipboard
+
+	// Refresh settings
+	watch           bool
+	refreshInterval time.Duration
+	updates         <-chan data.DashboardData // Set while watching files
 }
 
 // NewModel creates a new application model.
-func NewModel(dataManager *data.Manager) Model {
+// A nil config uses the defaults.
+func NewModel(dataManager *data.Manager, cfg *config.Config) Model {
+	if cfg == nil {
+		cfg = config.DefaultConfig()
+	}
+	refreshInterval := time.Duration(cfg.RefreshInterval) * time.Second
+	if refreshInterval <= 0 {
+		refreshInterval = data.SessionsTTL
+	}
+
+	// A blank resume_command falls back to the default
+	resumeCommand := cfg.ResumeCommand
+	if strings.TrimSpace(resumeCommand) == "" {
+		resumeCommand = config.DefaultConfig().ResumeCommand
+	}
+
+	// An invalid default range is reported at startup; show all time
+	timeRange, err := data.ParseTimeRange(cfg.DefaultTimeRange)
+	if err != nil {
+		timeRange = data.TimeAll
+	}
+
 	return Model{
-		dataManager: dataManager,
-		focused:     PanelStats,
-		header:      NewHeaderModel(),
-		stats:       NewStatsModel(),
-		activity:    NewActivityModel(),
-		projects:    NewProjectsModel(),
-		sessions:    NewSessionsModel(),
-		help:        NewHelpModel(),
-		detail:      NewDetailModal(),
+		dataManager:     dataManager,
+		resumeCommand:   resumeCommand,
+		editorCommand:   cfg.EditorCommand,
+		clipboard:       clipboard.New(cfg.Clipboard),
+		watch:           cfg.Watch,
+		refreshInterval: refreshInterval,
+		timeRange:       timeRange,
+		focused:         PanelStats,
+		topRight:        PanelProjects,
+		header:          NewHeaderModel(),
+		stats:           NewStatsModel(),
+		activity:        NewActivityModel(),
+		projects:        NewProjectsModel(),
+		sessions:        NewSessionsModel(),
+		tools:           NewToolsModel(),
+		files:           NewFilesModel(),
+		help:            NewHelpModel(),
+		detail:          NewDetailModal(),
+		transcript:      NewTranscriptModal(),
+		diagnostics:     NewDiagnosticsModal(),
+		search:          NewSearchModal(),
+		yank:            NewYankMenu(),
+		ranges:          NewRangePicker(),
+		project:         NewProjectView(),
 	}
 }
 
@@ -82,10 +170,33 @@ func (m Model) Init() tea.Cmd {
 	return tea.Batch(
 		m.loadData(),
 		m.vmTickCmd(),
-		m.sessionsTickCmd(),
+		pulseTickCmd(),
+		m.startRefresh(),
 	)
 }
 
+// startRefresh starts file watching if enabled, otherwise polling.
+func (m Model) startRefresh() tea.Cmd {
+	if !m.watch {
+		return m.sessionsTickCmd()
+	}
+	return func() tea.Msg {
+		updates, err := m.dataManager.Watch()
+		return watchStartedMsg{updates: updates, err: err}
+	}
+}
+
+// waitForUpdate waits for the next data refresh from the file watcher.
+func waitForUpdate(updates <-chan data.DashboardData) tea.Cmd {
+	return func() tea.Msg {
+		dashData, ok := <-updates
+		if !ok {
+			return watchStartedMsg{err: errors.New("file watcher stopped")}
+		}
+		return watchUpdateMsg{data: dashData}
+	}
+}
+
 func (m Model) loadData() tea.Cmd {
 	return func() tea.Msg {
 		return m.dataManager.GetDashboardData(false)
@@ -98,8 +209,14 @@ func (m Model) vmTickCmd() tea.Cmd {
 	})
 }
 
+func pulseTickCmd() tea.Cmd {
+	return tea.Tick(600*time.Millisecond, func(t time.Time) tea.Msg {
+		return pulseTickMsg{}
+	})
+}
+
 func (m Model) sessionsTickCmd() tea.Cmd {
-	return tea.Tick(10*time.Second, func(t time.Time) tea.Msg {
+	return tea.Tick(m.refreshInterval, func(t time.Time) tea.Msg {
 		return sessionsTickMsg{}
 	})
 }
@@ -128,20 +245,98 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if !m.paused {
 			vmStatus := m.dataManager.GetVMStatus(false)
 			m.header.Update(vmStatus, m.paused)
+			if m.dashData != nil {
+				m.refreshLive(vmStatus)
+			}
 		}
 		return m, m.vmTickCmd()
 
+	case pulseTickMsg:
+		if m.sessions.HasLive() {
+			m.sessions.TogglePulse()
+		}
+		return m, pulseTickCmd()
+
 	case sessionsTickMsg:
 		if !m.paused {
-			return m, m.loadData()
+			return m, tea.Batch(m.loadData(), m.sessionsTickCmd())
 		}
 		return m, m.sessionsTickCmd()
+
+	case watchStartedMsg:
+		if msg.err != nil {
+			// Fall back to polling
+			m.updates = nil
+			return m, m.sessionsTickCmd()
+		}
+		m.updates = msg.updates
+		return m, waitForUpdate(m.updates)
+
+	case watchUpdateMsg:
+		if !m.paused {
+			m.dashData = &msg.data
+			m.updateWidgets()
+		}
+		return m, waitForUpdate(m.updates)
+
+	case searchResultsMsg:
+		m.search.SetResults(msg.seq, msg.results)
+		return m, nil
+
+	case execFinishedMsg:
+		if msg.err != nil {
+			m.flashMessage = msg.action + " failed: " + msg.err.Error()
+			m.flashExpiry = time.Now().Add(3 * time.Second)
+		}
+		// The command may have added to the session
+		return m, m.loadData()
 	}
 
 	return m, nil
 }
 
 func (m Model) handleKeypress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// Transcript modal intercepts keys when visible
+	if m.transcript.IsVisible() {
+		return m.handleTranscriptKey(msg)
+	}
+
+	// Yank menu intercepts keys when visible
+	if m.yank.IsVisible() {
+		return m.handleYankKey(msg)
+	}
+
+	// Range picker intercepts keys when visible
+	if m.ranges.IsVisible() {
+		return m.handleRangeKey(msg)
+	}
+
+	// Search modal intercepts keys when visible
+	if m.search.IsVisible() {
+		return m.handleSearchKey(msg)
+	}
+
+	// Diagnostics modal intercepts keys when visible
+	if m.diagnostics.IsVisible() {
+		switch msg.String() {
+		case "esc", "q", "!":
+			m.diagnostics.Hide()
+		case "j", "up":
+			m.diagnostics.ScrollUp(1)
+		case "k", "down":
+			m.diagnostics.ScrollDown(1)
+		case "u", "pgup":
+			m.diagnostics.ScrollUp(10)
+		case "i", "pgdown":
+			m.diagnostics.ScrollDown(10)
+		case "g", "home":
+			m.diagnostics.GotoTop()
+		case "G", "end":
+			m.diagnostics.GotoBottom()
+		}
+		return m, nil
+	}
+
 	// Detail modal intercepts keys when visible
 	if m.detail.IsVisible() {
 		switch msg.String() {
@@ -150,7 +345,7 @@ func (m Model) handleKeypress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		case "y":
 			// Copy session ID from detail view
 			if m.detail.session != nil {
-				m.copySessionIDDirect(m.detail.session.SessionID)
+				m.copyText("Session ID", m.detail.session.SessionID)
 			}
 		}
 		return m, nil
@@ -165,6 +360,13 @@ func (m Model) handleKeypress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
+	// The project view covers the panels and takes their keys
+	if m.project.IsVisible() {
+		if cmd, handled := m.handleProjectKey(msg); handled {
+			return m, cmd
+		}
+	}
+
 	// Handle filter mode input
 	if m.isFilterMode() {
 		return m.handleFilterInput(msg)
@@ -188,6 +390,10 @@ func (m Model) handleKeypress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		if m.dashData != nil {
 			m.header.Update(m.dashData.VMStatus, m.paused)
 		}
+		if !m.paused {
+			// Pick up changes skipped while paused
+			return m, m.loadData()
+		}
 		return m, nil
 
 	// Panel jump
@@ -199,6 +405,10 @@ func (m Model) handleKeypress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.focusPanel(PanelProjects)
 	case "4":
 		m.focusPanel(PanelSessions)
+	case "5":
+		m.focusPanel(PanelTools)
+	case "6":
+		m.focusPanel(PanelFiles)
 
 	// Vim navigation between panels
 	case "h":
@@ -212,6 +422,15 @@ func (m Model) handleKeypress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "shift+tab":
 		m.focusPrevious()
 
+	// Stats panel: toggle model breakdown; Files panel: files or directories
+	case "v":
+		switch m.focused {
+		case PanelStats:
+			m.stats.CycleView()
+		case PanelFiles:
+			m.files.ToggleView()
+		}
+
 	// Activity panel: cycle metric
 	case "m":
 		if m.focused == PanelActivity {
@@ -227,6 +446,10 @@ func (m Model) handleKeypress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.cursorUp5()
 	case "i":
 		m.cursorDown5()
+	case "g", "home":
+		m.cursorTop()
+	case "G", "end":
+		m.cursorBottom()
 
 	// Sort
 	case "s":
@@ -236,7 +459,7 @@ func (m Model) handleKeypress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	// Time range
 	case "t":
-		m.cycleTimeRange()
+		m.ranges.Show(m.timeRange)
 
 	// Theme
 	case "T":
@@ -246,14 +469,47 @@ func (m Model) handleKeypress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "/":
 		m.enterFilterMode()
 
-	// Copy session ID
+	// Copy menu
 	case "y":
-		m.copySessionID()
+		m.openYankMenu()
 
-	// Open detail modal
+	// Open the project, the file's sessions, or the session's transcript
 	case "enter":
+		switch m.focused {
+		case PanelProjects:
+			m.openProject()
+		case PanelFiles:
+			m.showFileSessions()
+		default:
+			m.openTranscript()
+		}
+
+	// Return to where the last drill-down started
+	case "esc":
+		m.goBack()
+
+	// Open detail modal
+	case "d":
 		m.openDetailModal()
 
+	// Resume the session, or open its project in an editor or shell
+	case "c":
+		return m, m.execSelected("Resume", m.resumeCommand)
+	case "e":
+		return m, m.execSelected("Editor", editorTemplate(m.editorCommand))
+	case "x":
+		return m, m.execSelected("Shell", shellTemplate())
+
+	// Full-text search
+	case "ctrl+f":
+		m.search.Show()
+
+	// Diagnostics
+	case "!":
+		if m.dashData != nil {
+			m.diagnostics.Show(m.dashData.Diagnostics)
+		}
+
 	// Help
 	case "?":
 		m.help.Toggle()
@@ -264,36 +520,46 @@ func (m Model) handleKeypress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 func (m *Model) focusPanel(index int) {
 	m.focused = index
+	if index == PanelProjects || index == PanelTools || index == PanelFiles {
+		m.topRight = index
+	}
 	m.updateFocusStates()
 }
 
 func (m *Model) focusNext() {
-	m.focused = (m.focused + 1) % panelCount
-	m.updateFocusStates()
+	m.focusPanel((m.focused + 1) % panelCount)
 }
 
 func (m *Model) focusPrevious() {
-	m.focused = (m.focused - 1 + panelCount) % panelCount
-	m.updateFocusStates()
+	m.focusPanel((m.focused - 1 + panelCount) % panelCount)
 }
 
 func (m *Model) navLeft() {
 	row, col := m.getPanelPosition(m.focused)
 	if col > 0 {
-		m.focused = panelGrid[row][col-1]
-		m.updateFocusStates()
+		m.focusPanel(m.gridPanel(row, col-1))
 	}
 }
 
 func (m *Model) navRight() {
 	row, col := m.getPanelPosition(m.focused)
 	if col < len(panelGrid[row])-1 {
-		m.focused = panelGrid[row][col+1]
-		m.updateFocusStates()
+		m.focusPanel(m.gridPanel(row, col+1))
+	}
+}
+
+// gridPanel returns the panel currently shown in a grid cell.
+func (m Model) gridPanel(row, col int) int {
+	if panelGrid[row][col] == PanelProjects {
+		return m.topRight
 	}
+	return panelGrid[row][col]
 }
 
 func (m Model) getPanelPosition(index int) (row, col int) {
+	if index == PanelTools || index == PanelFiles {
+		index = PanelProjects
+	}
 	for r, rowPanels := range panelGrid {
 		for c, panelIdx := range rowPanels {
 			if panelIdx == index {
@@ -310,6 +576,10 @@ func (m *Model) cursorDown() {
 		m.projects.CursorDown()
 	case PanelSessions:
 		m.sessions.CursorDown()
+	case PanelTools:
+		m.tools.CursorDown()
+	case PanelFiles:
+		m.files.CursorDown()
 	}
 }
 
@@ -319,6 +589,10 @@ func (m *Model) cursorUp() {
 		m.projects.CursorUp()
 	case PanelSessions:
 		m.sessions.CursorUp()
+	case PanelTools:
+		m.tools.CursorUp()
+	case PanelFiles:
+		m.files.CursorUp()
 	}
 }
 
@@ -328,6 +602,10 @@ func (m *Model) cursorUp5() {
 		m.projects.CursorUpN(5)
 	case PanelSessions:
 		m.sessions.CursorUpN(5)
+	case PanelTools:
+		m.tools.CursorUpN(5)
+	case PanelFiles:
+		m.files.CursorUpN(5)
 	}
 }
 
@@ -337,6 +615,36 @@ func (m *Model) cursorDown5() {
 		m.projects.CursorDownN(5)
 	case PanelSessions:
 		m.sessions.CursorDownN(5)
+	case PanelTools:
+		m.tools.CursorDownN(5)
+	case PanelFiles:
+		m.files.CursorDownN(5)
+	}
+}
+
+func (m *Model) cursorTop() {
+	switch m.focused {
+	case PanelProjects:
+		m.projects.CursorTop()
+	case PanelSessions:
+		m.sessions.CursorTop()
+	case PanelTools:
+		m.tools.CursorTop()
+	case PanelFiles:
+		m.files.CursorTop()
+	}
+}
+
+func (m *Model) cursorBottom() {
+	switch m.focused {
+	case PanelProjects:
+		m.projects.CursorBottom()
+	case PanelSessions:
+		m.sessions.CursorBottom()
+	case PanelTools:
+		m.tools.CursorBottom()
+	case PanelFiles:
+		m.files.CursorBottom()
 	}
 }
 
@@ -346,6 +654,10 @@ func (m *Model) cycleSort() {
 		m.projects.CycleSort()
 	case PanelSessions:
 		m.sessions.CycleSort()
+	case PanelTools:
+		m.tools.CycleSort()
+	case PanelFiles:
+		m.files.CycleSort()
 	}
 }
 
@@ -355,20 +667,76 @@ func (m *Model) toggleSortDirection() {
 		m.projects.ToggleSortDirection()
 	case PanelSessions:
 		m.sessions.ToggleSortDirection()
+	case PanelTools:
+		m.tools.ToggleSortDirection()
+	case PanelFiles:
+		m.files.ToggleSortDirection()
 	}
 }
 
-func (m *Model) cycleTimeRange() {
-	m.timeRange = (m.timeRange + 1) % 4
+// setTimeRange filters every panel by a new time range.
+func (m *Model) setTimeRange(tr data.TimeRange) {
+	m.timeRange = tr
 	m.updateWidgets()
 }
 
+// handleRangeKey chooses a time range in the range picker.
+func (m Model) handleRangeKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	key := msg.String()
+	if m.ranges.IsEditing() {
+		switch key {
+		case "esc":
+			m.ranges.StopEditing()
+		case "enter":
+			if tr, ok := m.ranges.ParseInput(); ok {
+				m.ranges.Hide()
+				m.setTimeRange(tr)
+			}
+		case "backspace":
+			m.ranges.HandleBackspace()
+		default:
+			if len(key) == 1 && key[0] >= 32 && key[0] <= 126 {
+				m.ranges.HandleInput(key)
+			}
+		}
+		return m, nil
+	}
+
+	item, ok := m.ranges.ItemForKey(key)
+	switch key {
+	case "esc", "q", "t":
+		m.ranges.Hide()
+		return m, nil
+	case "j", "up":
+		m.ranges.CursorUp()
+		return m, nil
+	case "k", "down":
+		m.ranges.CursorDown()
+		return m, nil
+	case "enter":
+		item, ok = m.ranges.Selected(), true
+	}
+	if ok {
+		if item.custom {
+			m.ranges.StartEditing()
+		} else {
+			m.ranges.Hide()
+			m.setTimeRange(item.tr)
+		}
+	}
+	return m, nil
+}
+
 func (m *Model) isFilterMode() bool {
 	switch m.focused {
 	case PanelProjects:
 		return m.projects.IsFilterMode()
 	case PanelSessions:
 		return m.sessions.IsFilterMode()
+	case PanelTools:
+		return m.tools.IsFilterMode()
+	case PanelFiles:
+		return m.files.IsFilterMode()
 	}
 	return false
 }
@@ -379,6 +747,10 @@ func (m *Model) enterFilterMode() {
 		m.projects.SetFilterMode(true)
 	case PanelSessions:
 		m.sessions.SetFilterMode(true)
+	case PanelTools:
+		m.tools.SetFilterMode(true)
+	case PanelFiles:
+		m.files.SetFilterMode(true)
 	}
 }
 
@@ -393,6 +765,10 @@ func (m Model) handleFilterInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.projects.SetFilterMode(false)
 		case PanelSessions:
 			m.sessions.SetFilterMode(false)
+		case PanelTools:
+			m.tools.SetFilterMode(false)
+		case PanelFiles:
+			m.files.SetFilterMode(false)
 		}
 	case "enter":
 		// Apply filter and exit filter mode (keep filter active)
@@ -401,6 +777,10 @@ func (m Model) handleFilterInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.projects.filterMode = false
 		case PanelSessions:
 			m.sessions.filterMode = false
+		case PanelTools:
+			m.tools.filterMode = false
+		case PanelFiles:
+			m.files.filterMode = false
 		}
 	case "backspace":
 		switch m.focused {
@@ -408,6 +788,10 @@ func (m Model) handleFilterInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.projects.HandleFilterBackspace()
 		case PanelSessions:
 			m.sessions.HandleFilterBackspace()
+		case PanelTools:
+			m.tools.HandleFilterBackspace()
+		case PanelFiles:
+			m.files.HandleFilterBackspace()
 		}
 	default:
 		// Add character to filter if it's a printable character
@@ -417,6 +801,10 @@ func (m Model) handleFilterInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				m.projects.HandleFilterInput(key)
 			case PanelSessions:
 				m.sessions.HandleFilterInput(key)
+			case PanelTools:
+				m.tools.HandleFilterInput(key)
+			case PanelFiles:
+				m.files.HandleFilterInput(key)
 			}
 		}
 	}
@@ -424,95 +812,384 @@ func (m Model) handleFilterInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
-func (m *Model) copySessionID() {
-	if m.focused != PanelSessions {
+// openYankMenu shows what can be copied for the selected session or project.
+func (m *Model) openYankMenu() {
+	if session := m.selectedSession(); session != nil {
+		m.yank.Show(session.Summary, sessionYankItems(session, m.resumeCommand))
 		return
 	}
+	if m.focused == PanelProjects && !m.project.IsVisible() {
+		if project := m.projects.GetSelected(); project != nil {
+			m.yank.Show(project.ProjectName, projectYankItems(project))
+		}
+	}
+}
 
-	session := m.sessions.GetSelected()
-	if session == nil {
-		return
+func (m Model) handleYankKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	key := msg.String()
+	switch key {
+	case "esc", "q":
+		m.yank.Hide()
+	case "j", "up":
+		m.yank.CursorUp()
+	case "k", "down":
+		m.yank.CursorDown()
+	case "enter":
+		if item, ok := m.yank.Selected(); ok {
+			m.yank.Hide()
+			m.copyText(item.label, item.text)
+		}
+	case "y":
+		// yy copies the first item, the session ID for sessions
+		if len(m.yank.items) > 0 {
+			m.yank.Hide()
+			m.copyText(m.yank.items[0].label, m.yank.items[0].text)
+		}
+	default:
+		if item, ok := m.yank.ItemForKey(key); ok {
+			m.yank.Hide()
+			m.copyText(item.label, item.text)
+		}
 	}
+	return m, nil
+}
 
-	// Copy to clipboard using pbcopy (macOS)
-	cmd := exec.Command("pbcopy")
-	stdin, err := cmd.StdinPipe()
+// copyText copies text to the clipboard and flashes the outcome.
+func (m *Model) copyText(label, text string) {
+	m.flashExpiry = time.Now().Add(2 * time.Second)
+	how, err := m.clipboard.Copy(text)
 	if err != nil {
-		m.flashMessage = "Copy failed"
-		m.flashExpiry = time.Now().Add(2 * time.Second)
+		m.flashMessage = "Copy failed: " + err.Error()
 		return
 	}
+	m.flashMessage = "Copied " + label + " via " + how
+}
 
-	if err := cmd.Start(); err != nil {
-		m.flashMessage = "Copy failed"
-		m.flashExpiry = time.Now().Add(2 * time.Second)
-		return
+func (m Model) handleTranscriptKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	key := msg.String()
+
+	if m.transcript.IsSearchMode() {
+		switch key {
+		case "esc":
+			m.transcript.SetSearchMode(false)
+		case "enter":
+			m.transcript.ApplySearch()
+		case "backspace":
+			m.transcript.HandleSearchBackspace()
+		default:
+			if len(key) == 1 && key[0] >= 32 && key[0] <= 126 {
+				m.transcript.HandleSearchInput(key)
+			}
+		}
+		return m, nil
 	}
 
-	stdin.Write([]byte(session.SessionID))
-	stdin.Close()
+	switch key {
+	case "esc", "q":
+		m.transcript.Hide()
+	case "j", "up":
+		m.transcript.ScrollUp(1)
+	case "k", "down":
+		m.transcript.ScrollDown(1)
+	case "u", "pgup", "ctrl+u":
+		m.transcript.PageUp()
+	case "i", "pgdown", "ctrl+d":
+		m.transcript.PageDown()
+	case "g", "home":
+		m.transcript.GotoTop()
+	case "G", "end":
+		m.transcript.GotoBottom()
+	case "tab":
+		m.transcript.SelectNext()
+	case "shift+tab":
+		m.transcript.SelectPrev()
+	case " ", "enter":
+		m.transcript.ToggleSelected()
+	case "e":
+		m.transcript.SetAllExpanded(true)
+	case "E":
+		m.transcript.SetAllExpanded(false)
+	case "/":
+		m.transcript.SetSearchMode(false)
+		m.transcript.SetSearchMode(true)
+	case "n":
+		m.transcript.NextMatch()
+	case "N":
+		m.transcript.PrevMatch()
+	case "y":
+		if m.transcript.session != nil {
+			m.copyText("Session ID", m.transcript.session.SessionID)
+		}
+	}
+	return m, nil
+}
 
-	if err := cmd.Wait(); err != nil {
-		m.flashMessage = "Copy failed"
-		m.flashExpiry = time.Now().Add(2 * time.Second)
-		return
+func (m Model) handleSearchKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	key := msg.String()
+	switch key {
+	case "esc", "ctrl+f":
+		m.search.Hide()
+	case "enter":
+		if result := m.search.GetSelected(); result != nil {
+			m.search.Hide()
+			m.showSession(result.SessionID)
+		}
+	case "up", "ctrl+p":
+		m.search.CursorUp(1)
+	case "down", "ctrl+n":
+		m.search.CursorDown(1)
+	case "pgup":
+		m.search.CursorUp(5)
+	case "pgdown":
+		m.search.CursorDown(5)
+	case "backspace":
+		if m.search.HandleBackspace() {
+			return m, m.searchCmd()
+		}
+	default:
+		if len(key) == 1 && key[0] >= 32 && key[0] <= 126 {
+			if m.search.HandleInput(key) {
+				return m, m.searchCmd()
+			}
+		}
 	}
+	return m, nil
+}
 
-	// Show truncated session ID in flash message
-	id := session.SessionID
-	if len(id) > 12 {
-		id = id[:12] + "..."
+// searchCmd runs the current full-text search in the background.
+func (m Model) searchCmd() tea.Cmd {
+	query, seq := m.search.Query()
+	manager := m.dataManager
+	return func() tea.Msg {
+		return searchResultsMsg{seq: seq, results: manager.Search(query, searchLimit)}
 	}
-	m.flashMessage = "Copied: " + id
-	m.flashExpiry = time.Now().Add(2 * time.Second)
 }
 
-func (m *Model) copySessionIDDirect(sessionID string) {
-	cmd := exec.Command("pbcopy")
-	stdin, err := cmd.StdinPipe()
-	if err != nil {
-		m.flashMessage = "Copy failed"
-		m.flashExpiry = time.Now().Add(2 * time.Second)
+// showSession selects a session in the Sessions panel, clearing its
+// filter and widening the time range if the session is hidden. Esc
+// returns to where the jump started.
+func (m *Model) showSession(sessionID string) {
+	m.pushLocation()
+	m.project.Hide()
+	m.focusPanel(PanelSessions)
+	if m.sessions.GetFilterQuery() != "" {
+		m.sessions.SetFilterMode(false)
+	}
+	if m.sessions.SelectSession(sessionID) {
 		return
 	}
+	if !m.timeRange.IsAll() {
+		m.setTimeRange(data.TimeAll)
+		if m.sessions.SelectSession(sessionID) {
+			return
+		}
+	}
+	m.flashMessage = "Session not listed"
+	m.flashExpiry = time.Now().Add(2 * time.Second)
+}
 
-	if err := cmd.Start(); err != nil {
-		m.flashMessage = "Copy failed"
-		m.flashExpiry = time.Now().Add(2 * time.Second)
+// showFileSessions lists the sessions that touched the selected file or
+// directory in the Sessions panel. Esc returns to the Files panel.
+func (m *Model) showFileSessions() {
+	q, ok := m.files.SessionsQuery()
+	if !ok {
 		return
 	}
+	m.pushLocation()
+	m.focusPanel(PanelSessions)
+	m.sessions.SetFilterQuery(q)
+}
 
-	stdin.Write([]byte(sessionID))
-	stdin.Close()
+// execSelected suspends the dashboard to run a command for the selected
+// session.
+func (m *Model) execSelected(action, template string) tea.Cmd {
+	session := m.selectedSession()
+	if session == nil {
+		return nil
+	}
+	cmd, err := execSession(action, template, session)
+	if err != nil {
+		m.flashMessage = action + " failed: " + err.Error()
+		m.flashExpiry = time.Now().Add(3 * time.Second)
+		return nil
+	}
+	return cmd
+}
 
-	if err := cmd.Wait(); err != nil {
-		m.flashMessage = "Copy failed"
-		m.flashExpiry = time.Now().Add(2 * time.Second)
-		return
+// selectedSession returns the session that session actions apply to:
+// the one selected in the project view or the Sessions panel.
+func (m Model) selectedSession() *data.SessionEntry {
+	if m.project.IsVisible() {
+		return m.project.GetSelected()
 	}
+	if m.focused == PanelSessions {
+		return m.sessions.GetSelected()
+	}
+	return nil
+}
 
-	id := sessionID
-	if len(id) > 12 {
-		id = id[:12] + "..."
+func (m *Model) openTranscript() {
+	session := m.selectedSession()
+	if session != nil {
+		m.transcript.Show(session)
 	}
-	m.flashMessage = "Copied: " + id
-	m.flashExpiry = time.Now().Add(2 * time.Second)
 }
 
 func (m *Model) openDetailModal() {
-	if m.focused != PanelSessions {
+	session := m.selectedSession()
+	if session != nil {
+		m.detail.Show(session)
+	}
+}
+
+// openProject shows the selected project full screen.
+func (m *Model) openProject() {
+	project := m.projects.GetSelected()
+	if project == nil {
 		return
 	}
+	m.pushLocation()
+	m.showProject(project.ProjectPath)
+}
 
-	session := m.sessions.GetSelected()
-	if session != nil {
-		m.detail.Show(session)
+// showProject shows a project full screen with all its sessions. It
+// reports false if the project is no longer in the data.
+func (m *Model) showProject(path string) bool {
+	project, sessions := m.projectData(path)
+	if project == nil {
+		return false
+	}
+	m.project.Show(project, sessions)
+	return true
+}
+
+// projectData returns a project's all-time summary and sessions, or nil
+// if the project is unknown.
+func (m Model) projectData(path string) (*data.ProjectSummary, []data.SessionEntry) {
+	if m.dashData == nil {
+		return nil, nil
+	}
+	var project *data.ProjectSummary
+	for i := range m.dashData.Projects {
+		if m.dashData.Projects[i].ProjectPath == path {
+			project = &m.dashData.Projects[i]
+			break
+		}
+	}
+	if project == nil {
+		return nil, nil
+	}
+	var sessions []data.SessionEntry
+	for _, s := range m.dashData.Sessions {
+		if s.ProjectPath == path {
+			sessions = append(sessions, s)
+		}
+	}
+	return project, sessions
+}
+
+// pushLocation remembers the current location for goBack.
+func (m *Model) pushLocation() {
+	loc := location{
+		focused:        m.focused,
+		topRight:       m.topRight,
+		sessionsFilter: m.sessions.GetFilterQuery(),
+	}
+	if m.project.IsVisible() {
+		loc.project = m.project.ProjectPath()
+		if session := m.project.GetSelected(); session != nil {
+			loc.session = session.SessionID
+		}
+	}
+	m.backStack = append(m.backStack, loc)
+}
+
+// goBack returns to the most recently left location. With nothing to go
+// back to, it closes the project view.
+func (m *Model) goBack() {
+	if len(m.backStack) == 0 {
+		m.project.Hide()
+		return
+	}
+	loc := m.backStack[len(m.backStack)-1]
+	m.backStack = m.backStack[:len(m.backStack)-1]
+
+	m.topRight = loc.topRight
+	m.focusPanel(loc.focused)
+	if m.sessions.GetFilterQuery() != loc.sessionsFilter {
+		m.sessions.SetFilterQuery(loc.sessionsFilter)
+	}
+	if loc.project != "" && m.showProject(loc.project) {
+		m.project.SelectSession(loc.session)
+		return
 	}
+	m.project.Hide()
+}
+
+// handleProjectKey handles keys in the project view. Keys it does not
+// handle, such as quit, search and help, work as on the dashboard.
+func (m *Model) handleProjectKey(msg tea.KeyMsg) (tea.Cmd, bool) {
+	switch msg.String() {
+	case "esc", "backspace":
+		m.goBack()
+	case "j", "up":
+		m.project.CursorUp(1)
+	case "k", "down":
+		m.project.CursorDown(1)
+	case "u", "pgup":
+		m.project.CursorUp(5)
+	case "i", "pgdown":
+		m.project.CursorDown(5)
+	case "g", "home":
+		m.project.CursorTop()
+	case "G", "end":
+		m.project.CursorBottom()
+	case "m":
+		m.project.CycleMetric()
+	case "enter":
+		m.openTranscript()
+	case "d":
+		m.openDetailModal()
+	case "y":
+		m.openYankMenu()
+	case "c":
+		return m.execSelected("Resume", m.resumeCommand), true
+	case "e":
+		return m.execSelected("Editor", editorTemplate(m.editorCommand)), true
+	case "x":
+		return m.execSelected("Shell", shellTemplate()), true
+	case "q", "ctrl+c", "r", "p", "T", "ctrl+f", "!", "?":
+		return nil, false
+	}
+	// Panel keys do nothing here
+	return nil, true
 }
 
 func (m Model) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
 	// Ignore mouse in modal mode
-	if m.help.IsVisible() || m.detail.IsVisible() {
+	if m.help.IsVisible() || m.detail.IsVisible() || m.diagnostics.IsVisible() || m.search.IsVisible() || m.yank.IsVisible() || m.ranges.IsVisible() {
+		return m, nil
+	}
+
+	// Wheel scrolls the transcript
+	if m.transcript.IsVisible() {
+		switch msg.Button {
+		case tea.MouseButtonWheelUp:
+			m.transcript.ScrollUp(3)
+		case tea.MouseButtonWheelDown:
+			m.transcript.ScrollDown(3)
+		}
+		return m, nil
+	}
+
+	// Wheel moves through the project's sessions
+	if m.project.IsVisible() {
+		switch msg.Button {
+		case tea.MouseButtonWheelUp:
+			m.project.CursorUp(1)
+		case tea.MouseButtonWheelDown:
+			m.project.CursorDown(1)
+		}
 		return m, nil
 	}
 
@@ -536,9 +1213,9 @@ func (m Model) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 	} else {
-		// Right side: Projects (top), Sessions (bottom)
+		// Right side: Projects, Tools or Files (top), Sessions (bottom)
 		if y > headerHeight && y < headerHeight+topHeight {
-			targetPanel = PanelProjects
+			targetPanel = m.topRight
 		} else if y >= headerHeight+topHeight {
 			targetPanel = PanelSessions
 		} else {
@@ -572,6 +1249,8 @@ func (m *Model) updateFocusStates() {
 	m.activity.SetFocused(m.focused == PanelActivity)
 	m.projects.SetFocused(m.focused == PanelProjects)
 	m.sessions.SetFocused(m.focused == PanelSessions)
+	m.tools.SetFocused(m.focused == PanelTools)
+	m.files.SetFocused(m.focused == PanelFiles)
 }
 
 func (m *Model) updateSizes() {
@@ -595,8 +1274,29 @@ func (m *Model) updateSizes() {
 	m.activity.SetSize(leftWidth, bottomHeight)
 	m.projects.SetSize(rightWidth, topHeight)
 	m.sessions.SetSize(rightWidth, bottomHeight)
+	m.tools.SetSize(rightWidth, topHeight)
+	m.files.SetSize(rightWidth, topHeight)
 	m.help.SetSize(m.width, m.height)
 	m.detail.SetSize(m.width, m.height)
+	m.diagnostics.SetSize(m.width, m.height)
+	m.search.SetSize(m.width, m.height)
+	m.yank.SetSize(m.width, m.height)
+	m.ranges.SetSize(m.width, m.height)
+	m.transcript.SetSize(m.width, m.height-1) // Below the header
+	m.project.SetSize(m.width, m.height-2)    // Between the header and footer
+}
+
+// refreshLive updates process stats and live session markers without
+// reloading session data.
+func (m *Model) refreshLive(vmStatus data.VMStatus) {
+	dashData := *m.dashData
+	dashData.VMStatus = vmStatus
+	dashData.Processes = m.dataManager.GetProcesses(false)
+	dashData.Sessions = data.MarkLiveSessions(dashData.Sessions, dashData.Processes)
+	m.dashData = &dashData
+
+	m.header.SetAgents(len(dashData.Processes))
+	m.sessions.Update(dashData.FilterSessions(m.timeRange), m.timeRange)
 }
 
 func (m *Model) updateWidgets() {
@@ -605,6 +1305,9 @@ func (m *Model) updateWidgets() {
 	}
 
 	m.header.Update(m.dashData.VMStatus, m.paused)
+	m.header.SetAgents(len(m.dashData.Processes))
+	m.header.SetProblems(len(m.dashData.Diagnostics.Errors))
+	m.diagnostics.Update(m.dashData.Diagnostics)
 	m.stats.Update(m.dashData, m.timeRange)
 	m.activity.Update(m.dashData, m.timeRange)
 
@@ -614,6 +1317,14 @@ func (m *Model) updateWidgets() {
 
 	m.projects.Update(filteredProjects, m.timeRange)
 	m.sessions.Update(filteredSessions, m.timeRange)
+	m.tools.Update(m.dashData.ToolBreakdown(m.timeRange), m.timeRange)
+	files := m.dashData.FileBreakdown(m.timeRange)
+	m.files.Update(files, data.DirectoryBreakdown(files), m.timeRange)
+	if m.project.IsVisible() {
+		if project, sessions := m.projectData(m.project.ProjectPath()); project != nil {
+			m.project.Update(project, sessions)
+		}
+	}
 	m.updateFocusStates()
 }
 
@@ -644,8 +1355,14 @@ func (m Model) View() string {
 	leftColumn := lipgloss.JoinVertical(lipgloss.Left, leftTop, leftBottom)
 	leftColumn = lipgloss.NewStyle().Width(leftWidth).Height(contentHeight).Render(leftColumn)
 
-	// Right column: Projects on top, Sessions on bottom
+	// Right column: Projects, Tools or Files on top, Sessions on bottom
 	rightTop := m.projects.View()
+	switch m.topRight {
+	case PanelTools:
+		rightTop = m.tools.View()
+	case PanelFiles:
+		rightTop = m.files.View()
+	}
 	rightBottom := m.sessions.View()
 	rightColumn := lipgloss.JoinVertical(lipgloss.Left, rightTop, rightBottom)
 	rightColumn = lipgloss.NewStyle().Width(rightWidth).Height(contentHeight).Render(rightColumn)
@@ -673,6 +1390,32 @@ func (m Model) View() string {
 		return m.detail.View()
 	}
 
+	if m.diagnostics.IsVisible() {
+		return m.diagnostics.View()
+	}
+
+	if m.search.IsVisible() {
+		return m.search.View()
+	}
+
+	if m.yank.IsVisible() {
+		return m.yank.View()
+	}
+
+	if m.ranges.IsVisible() {
+		return m.ranges.View()
+	}
+
+	// Transcript takes the whole screen
+	if m.transcript.IsVisible() {
+		return m.header.View() + "\n" + m.transcript.View()
+	}
+
+	// Project view replaces the panels
+	if m.project.IsVisible() {
+		return m.header.View() + "\n" + m.project.View() + "\n" + footer
+	}
+
 	return view
 }
 
@@ -700,6 +1443,13 @@ func (m Model) renderFooter() string {
 		panelBindings = m.projects.GetKeybindings()
 	case PanelSessions:
 		panelBindings = m.sessions.GetKeybindings()
+	case PanelTools:
+		panelBindings = m.tools.GetKeybindings()
+	case PanelFiles:
+		panelBindings = m.files.GetKeybindings()
+	}
+	if m.project.IsVisible() {
+		panelBindings = m.project.GetKeybindings()
 	}
 
 	// Add panel-specific bindings first (highlighted - these are dynamic)
@@ -717,7 +1467,7 @@ func (m Model) renderFooter() string {
 		{"r", "refresh"},
 		{"p", "pause"},
 		{"t", m.timeRange.String()},
-		{"1-4", "jump"},
+		{"1-6", "jump"},
 		{"?", "help"},
 	}
 
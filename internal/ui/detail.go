@@ -2,10 +2,12 @@ package ui
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/moshe-exe/lazyvibe/internal/data"
+	"github.com/moshe-exe/lazyvibe/internal/util"
 )
 
 // DetailModal represents a modal for showing session details.
@@ -59,8 +61,6 @@ func (d DetailModal) View() string {
 		modalWidth = 80
 	}
 
-	modalHeight := 16
-
 	var lines []string
 
 	// Title
@@ -74,6 +74,9 @@ func (d DetailModal) View() string {
 	lines = append(lines, d.detailLine("Session ID:", session.SessionID))
 	lines = append(lines, d.detailLine("Project:", session.ProjectName))
 	lines = append(lines, d.detailLine("Path:", truncateMiddle(session.ProjectPath, modalWidth-20)))
+	if session.Source != "" {
+		lines = append(lines, d.detailLine("Source:", truncateMiddle(session.Source, modalWidth-20)))
+	}
 
 	if session.GitBranch != nil && *session.GitBranch != "" {
 		lines = append(lines, d.detailLine("Branch:", *session.GitBranch))
@@ -82,10 +85,19 @@ func (d DetailModal) View() string {
 	lines = append(lines, "")
 	lines = append(lines, d.detailLine("Messages:", fmt.Sprintf("%d", session.MessageCount)))
 	lines = append(lines, d.detailLine("Duration:", session.FormatDuration()))
-	lines = append(lines, d.detailLine("Created:", session.Created.Format("2006-01-02 15:04")))
-	lines = append(lines, d.detailLine("Modified:", session.Modified.Format("2006-01-02 15:04")))
+	lines = append(lines, d.detailLine("Created:", util.FormatTime(session.Created, "2006-01-02 15:04")))
+	lines = append(lines, d.detailLine("Modified:", util.FormatTime(session.Modified, "2006-01-02 15:04")))
+	if !session.TimesKnown() {
+		lines = append(lines, WarningStyle.Render("⚠ Timestamps missing or invalid; see ! diagnostics"))
+	}
+	if session.IsLive() {
+		proc := session.Process
+		lines = append(lines, d.detailLine("Live:", fmt.Sprintf("PID %d | CPU %.0f%% | %.0fMB", proc.PID, proc.CPUPercent, proc.MemoryMB)))
+	}
 
 	lines = append(lines, "")
+	lines = append(lines, d.commitLines(modalWidth-4)...)
+	lines = append(lines, d.fileLines(modalWidth-4)...)
 
 	// Summary
 	lines = append(lines, d.detailLine("Summary:", ""))
@@ -96,6 +108,7 @@ func (d DetailModal) View() string {
 	lines = append(lines, "  "+MutedStyle.Render(summary))
 
 	content := strings.Join(lines, "\n")
+	modalHeight := max(16, len(lines))
 
 	// Modal style
 	modalStyle := lipgloss.NewStyle().
@@ -131,6 +144,92 @@ func (d DetailModal) View() string {
 	return topPadding + strings.Join(lines, "\n")
 }
 
+// maxDetailCommits caps the commits listed in the detail modal.
+const maxDetailCommits = 8
+
+// commitLines renders the commits made during the session.
+func (d DetailModal) commitLines(width int) []string {
+	git := d.session.Git
+	if git == nil {
+		if !d.session.TimesKnown() {
+			return nil
+		}
+		return []string{d.detailLine("Commits:", MutedStyle.Render("no git repository found")), ""}
+	}
+	if len(git.Commits) == 0 {
+		return []string{d.detailLine("Commits:", "none"), ""}
+	}
+
+	lines := []string{d.detailLine("Commits:", fmt.Sprintf("%d  %s", len(git.Commits), lineChanges(git.Added, git.Removed)))}
+	shown := git.Commits
+	if len(shown) > maxDetailCommits {
+		shown = shown[len(shown)-maxDetailCommits:]
+	}
+	for _, c := range shown {
+		hash := c.Hash
+		if len(hash) > 7 {
+			hash = hash[:7]
+		}
+		prefix := fmt.Sprintf("  %s %s ", hash, c.Time.Format("15:04"))
+		changes := " " + lineChanges(c.Added, c.Removed)
+		subject := truncate(c.Subject, max(width-len(prefix)-lipgloss.Width(changes), 10))
+		lines = append(lines, MutedStyle.Render(prefix)+subject+changes)
+	}
+	if more := len(git.Commits) - len(shown); more > 0 {
+		lines = append(lines, MutedStyle.Render(fmt.Sprintf("  … and %d earlier", more)))
+	}
+	return append(lines, "")
+}
+
+// maxDetailFiles caps the edited files listed in the detail modal.
+const maxDetailFiles = 5
+
+// fileLines renders the files the session edited, most edited first.
+func (d DetailModal) fileLines(width int) []string {
+	files := d.session.Files
+	if len(files) == 0 {
+		return nil
+	}
+
+	var edited []string
+	read := 0
+	for path, f := range files {
+		if f.Edits > 0 {
+			edited = append(edited, path)
+		}
+		if f.Reads > 0 {
+			read++
+		}
+	}
+	sort.Slice(edited, func(i, j int) bool {
+		if files[edited[i]].Edits != files[edited[j]].Edits {
+			return files[edited[i]].Edits > files[edited[j]].Edits
+		}
+		return edited[i] < edited[j]
+	})
+
+	lines := []string{d.detailLine("Files:", fmt.Sprintf("%d edited · %d read", len(edited), read))}
+	shown := edited
+	if len(shown) > maxDetailFiles {
+		shown = shown[:maxDetailFiles]
+	}
+	for _, path := range shown {
+		edits := fmt.Sprintf("%4d× ", files[path].Edits)
+		name := fileLabel(d.session.ProjectName, data.RelativePath(d.session.ProjectPath, path), path)
+		lines = append(lines, MutedStyle.Render("  "+edits)+truncateMiddle(name, max(width-lipgloss.Width(edits)-2, 10)))
+	}
+	if more := len(edited) - len(shown); more > 0 {
+		lines = append(lines, MutedStyle.Render(fmt.Sprintf("  … and %d more", more)))
+	}
+	return append(lines, "")
+}
+
+// lineChanges renders lines added and removed, e.g. "+120 -45".
+func lineChanges(added, removed int) string {
+	return lipgloss.NewStyle().Foreground(Success).Render(fmt.Sprintf("+%s", formatNumber(added))) + " " +
+		lipgloss.NewStyle().Foreground(Error).Render(fmt.Sprintf("-%s", formatNumber(removed)))
+}
+
 func (d DetailModal) detailLine(label, value string) string {
 	labelRendered := StatLabelStyle.Render(fmt.Sprintf("%-12s", label))
 	valueRendered := StatValueStyle.Render(value)
@@ -0,0 +1,192 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/moshe-exe/lazyvibe/internal/data"
+)
+
+// DiagnosticsModal represents a scrollable report of data problems.
+type DiagnosticsModal struct {
+	visible bool
+	diag    data.Diagnostics
+	offset  int // First visible line
+	width   int
+	height  int
+}
+
+// NewDiagnosticsModal creates a new diagnostics modal.
+func NewDiagnosticsModal() DiagnosticsModal {
+	return DiagnosticsModal{}
+}
+
+// SetSize sets the modal dimensions.
+func (d *DiagnosticsModal) SetSize(width, height int) {
+	d.width = width
+	d.height = height
+}
+
+// Show displays the modal with the given diagnostics.
+func (d *DiagnosticsModal) Show(diag data.Diagnostics) {
+	d.diag = diag
+	d.offset = 0
+	d.visible = true
+}
+
+// Update refreshes the diagnostics shown, keeping the scroll position.
+func (d *DiagnosticsModal) Update(diag data.Diagnostics) {
+	d.diag = diag
+	d.clampOffset()
+}
+
+// Hide hides the modal.
+func (d *DiagnosticsModal) Hide() {
+	d.visible = false
+}
+
+// IsVisible returns whether the modal is visible.
+func (d *DiagnosticsModal) IsVisible() bool {
+	return d.visible
+}
+
+// ScrollUp scrolls up by n lines.
+func (d *DiagnosticsModal) ScrollUp(n int) {
+	d.offset -= n
+	d.clampOffset()
+}
+
+// ScrollDown scrolls down by n lines.
+func (d *DiagnosticsModal) ScrollDown(n int) {
+	d.offset += n
+	d.clampOffset()
+}
+
+// GotoTop scrolls to the first line.
+func (d *DiagnosticsModal) GotoTop() {
+	d.offset = 0
+}
+
+// GotoBottom scrolls to the last page.
+func (d *DiagnosticsModal) GotoBottom() {
+	d.offset = len(d.lines())
+	d.clampOffset()
+}
+
+func (d *DiagnosticsModal) clampOffset() {
+	maxOffset := len(d.lines()) - d.visibleLines()
+	if d.offset > maxOffset {
+		d.offset = maxOffset
+	}
+	if d.offset < 0 {
+		d.offset = 0
+	}
+}
+
+// modalWidth returns the width of the modal box.
+func (d DiagnosticsModal) modalWidth() int {
+	width := d.width * 80 / 100
+	if width < 50 {
+		width = 50
+	}
+	return width
+}
+
+// visibleLines returns the number of report lines that fit.
+func (d DiagnosticsModal) visibleLines() int {
+	// Border, padding, title and separator
+	return max(d.height-10, 3)
+}
+
+// lines renders the report body.
+func (d DiagnosticsModal) lines() []string {
+	textWidth := d.modalWidth() - 8
+	var lines []string
+
+	for _, root := range d.diag.Roots {
+		lines = append(lines, d.detailLine("Directory:", truncateMiddle(root, textWidth-14)))
+	}
+	lines = append(lines, d.detailLine("Indexes:", formatNumber(d.diag.IndexFiles)))
+	lines = append(lines, d.detailLine("Transcripts:", formatNumber(d.diag.Transcripts)))
+	lines = append(lines, "")
+
+	if !d.diag.HasProblems() {
+		lines = append(lines, SuccessStyle.Render("No problems found"))
+		return lines
+	}
+
+	for _, kind := range data.ErrorKinds {
+		errs := d.diag.ByKind(kind)
+		if len(errs) == 0 {
+			continue
+		}
+		lines = append(lines, WarningStyle.Bold(true).Render(fmt.Sprintf("%s (%d)", kind, len(errs))))
+		for _, e := range errs {
+			location := e.Path
+			if e.Line > 0 {
+				location += fmt.Sprintf(":%d", e.Line)
+			}
+			lines = append(lines, "  "+MutedStyle.Render(e.Time.Format("15:04:05"))+" "+truncateMiddle(location, textWidth-11))
+			lines = append(lines, "    "+MutedStyle.Render(truncate(e.Message, textWidth-4)))
+		}
+		lines = append(lines, "")
+	}
+	return lines
+}
+
+// View renders the diagnostics modal.
+func (d DiagnosticsModal) View() string {
+	if !d.visible {
+		return ""
+	}
+
+	modalWidth := d.modalWidth()
+
+	title := PanelTitleStyle.Render("Diagnostics")
+	if n := len(d.diag.Errors); n > 0 {
+		title += WarningStyle.Render(fmt.Sprintf(" %d problems", n))
+	}
+	header := []string{
+		title,
+		MutedStyle.Render(strings.Repeat("-", modalWidth-4)),
+	}
+
+	body := d.lines()
+	visible := d.visibleLines()
+	end := min(d.offset+visible, len(body))
+	content := strings.Join(append(header, body[d.offset:end]...), "\n")
+
+	modalStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(Warning).
+		Padding(1, 2).
+		Width(modalWidth).
+		Height(visible + len(header))
+
+	modal := modalStyle.Render(content)
+
+	// Center the modal
+	paddingLeft := (d.width - modalWidth) / 2
+	paddingTop := (d.height - visible - len(header) - 4) / 2
+	if paddingLeft < 0 {
+		paddingLeft = 0
+	}
+	if paddingTop < 0 {
+		paddingTop = 0
+	}
+
+	leftPadding := strings.Repeat(" ", paddingLeft)
+	lines := strings.Split(modal, "\n")
+	for i, line := range lines {
+		lines[i] = leftPadding + line
+	}
+
+	return strings.Repeat("\n", paddingTop) + strings.Join(lines, "\n")
+}
+
+func (d DiagnosticsModal) detailLine(label, value string) string {
+	labelRendered := StatLabelStyle.Render(fmt.Sprintf("%-14s", label))
+	valueRendered := StatValueStyle.Render(value)
+	return labelRendered + valueRendered
+}
@@ -0,0 +1,115 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/moshe-exe/lazyvibe/internal/data"
+)
+
+// execFinishedMsg reports that a command run from the dashboard exited.
+type execFinishedMsg struct {
+	action string
+	err    error
+}
+
+// sessionCommand builds a command from a template for a session. The
+// template is split on spaces before {id} and {project} are replaced, so
+// paths with spaces stay one argument. It runs in the project directory.
+func sessionCommand(template string, session *data.SessionEntry) (*exec.Cmd, error) {
+	args := commandArgs(template, session)
+	if len(args) == 0 {
+		return nil, errors.New("empty command")
+	}
+
+	dir, err := projectDir(session)
+	if err != nil {
+		return nil, err
+	}
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Dir = dir
+	return cmd, nil
+}
+
+// commandArgs splits a command template and fills in a session's values.
+func commandArgs(template string, session *data.SessionEntry) []string {
+	args := strings.Fields(template)
+	replacer := strings.NewReplacer("{id}", session.SessionID, "{project}", session.ProjectPath)
+	for i, arg := range args {
+		args[i] = replacer.Replace(arg)
+	}
+	return args
+}
+
+// resumeCommandLine returns a shell command line that resumes a session
+// from any directory.
+func resumeCommandLine(template string, session *data.SessionEntry) string {
+	args := commandArgs(template, session)
+	for i, arg := range args {
+		args[i] = shellQuote(arg)
+	}
+	line := strings.Join(args, " ")
+	if session.ProjectPath != "" {
+		line = "cd " + shellQuote(session.ProjectPath) + " && " + line
+	}
+	return line
+}
+
+// shellQuote quotes s for a POSIX shell if it contains special characters.
+func shellQuote(s string) string {
+	if s != "" && strings.Trim(s, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_./:=@%+,") == "" {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// editorTemplate returns the command template opening a project: the
+// configured one, else $VISUAL or $EDITOR, else vi.
+func editorTemplate(configured string) string {
+	for _, editor := range []string{configured, os.Getenv("VISUAL"), os.Getenv("EDITOR")} {
+		if strings.TrimSpace(editor) == "" {
+			continue
+		}
+		if !strings.Contains(editor, "{project}") {
+			editor += " {project}"
+		}
+		return editor
+	}
+	return "vi {project}"
+}
+
+// shellTemplate returns the user's shell, else sh.
+func shellTemplate() string {
+	if shell := os.Getenv("SHELL"); shell != "" {
+		return shell
+	}
+	return "sh"
+}
+
+// projectDir returns the session's project directory if it still exists.
+func projectDir(session *data.SessionEntry) (string, error) {
+	if session.ProjectPath == "" {
+		return "", errors.New("project directory unknown")
+	}
+	info, err := os.Stat(session.ProjectPath)
+	if err != nil || !info.IsDir() {
+		return "", fmt.Errorf("%s not found", session.ProjectPath)
+	}
+	return session.ProjectPath, nil
+}
+
+// execSession suspends the dashboard to run a command for a session and
+// restores it when the command exits.
+func execSession(action, template string, session *data.SessionEntry) (tea.Cmd, error) {
+	cmd, err := sessionCommand(template, session)
+	if err != nil {
+		return nil, err
+	}
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return execFinishedMsg{action: action, err: err}
+	}), nil
+}
@@ -0,0 +1,496 @@
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/moshe-exe/lazyvibe/internal/data"
+	"github.com/moshe-exe/lazyvibe/internal/util"
+)
+
+// FileSortField represents the field to sort files by.
+type FileSortField int
+
+const (
+	FileSortByEdits FileSortField = iota
+	FileSortByReads
+	FileSortBySessions
+	FileSortByName
+	FileSortByRecent
+)
+
+// fileRow is a file or directory listed in the Files panel.
+type fileRow struct {
+	path     string // Absolute path
+	label    string // Project name and path within the project
+	edits    int
+	reads    int
+	sessions int
+	last     time.Time
+}
+
+// FilesModel represents the files-touched table component. It lists
+// either files or the directories containing them.
+type FilesModel struct {
+	files       []data.FileUsage
+	dirs        []data.DirectoryUsage
+	rows        []fileRow
+	allRows     []fileRow // Unfiltered list
+	showDirs    bool
+	cursor      int
+	offset      int
+	focused     bool
+	width       int
+	height      int
+	sortField   FileSortField
+	sortDesc    bool
+	filterQuery string
+	filterMode  bool
+	timeRange   data.TimeRange
+}
+
+// NewFilesModel creates a new files model.
+func NewFilesModel() FilesModel {
+	return FilesModel{
+		sortField: FileSortByEdits, // Default sort by edit count
+		sortDesc:  true,            // Most edited first
+	}
+}
+
+// Update updates the files and directories data.
+func (f *FilesModel) Update(files []data.FileUsage, dirs []data.DirectoryUsage, timeRange data.TimeRange) {
+	f.files = files
+	f.dirs = dirs
+	f.timeRange = timeRange
+	f.buildRows()
+
+	// Reset cursor if out of bounds
+	if f.cursor >= len(f.rows) {
+		f.cursor = max(0, len(f.rows)-1)
+	}
+}
+
+// buildRows lists the files or directories, then filters and sorts them.
+func (f *FilesModel) buildRows() {
+	f.allRows = nil
+	if f.showDirs {
+		for _, d := range f.dirs {
+			f.allRows = append(f.allRows, fileRow{
+				path:     d.Path,
+				label:    fileLabel(d.ProjectName, d.RelPath, d.Path),
+				edits:    d.Edits,
+				reads:    d.Reads,
+				sessions: d.Sessions,
+				last:     d.LastTouched,
+			})
+		}
+	} else {
+		for _, file := range f.files {
+			f.allRows = append(f.allRows, fileRow{
+				path:     file.Path,
+				label:    fileLabel(file.ProjectName, file.RelPath, file.Path),
+				edits:    file.Edits,
+				reads:    file.Reads,
+				sessions: len(file.Sessions),
+				last:     file.LastTouched,
+			})
+		}
+	}
+	f.applyFilter()
+	f.sortRows()
+}
+
+// fileLabel names a path by its project, or by itself if it lies outside
+// the project.
+func fileLabel(project, rel, path string) string {
+	if rel == path || project == "" {
+		return path
+	}
+	if rel == "." {
+		return project + string(filepath.Separator)
+	}
+	return project + string(filepath.Separator) + rel
+}
+
+// ToggleView switches between listing files and directories.
+func (f *FilesModel) ToggleView() {
+	f.showDirs = !f.showDirs
+	f.buildRows()
+	f.cursor = 0
+	f.offset = 0
+}
+
+// applyFilter filters rows based on the current filter query.
+func (f *FilesModel) applyFilter() {
+	if f.filterQuery == "" {
+		f.rows = make([]fileRow, len(f.allRows))
+		copy(f.rows, f.allRows)
+		return
+	}
+
+	query := strings.ToLower(f.filterQuery)
+	var filtered []fileRow
+	for _, row := range f.allRows {
+		if strings.Contains(strings.ToLower(row.label), query) ||
+			strings.Contains(strings.ToLower(row.path), query) {
+			filtered = append(filtered, row)
+		}
+	}
+	f.rows = filtered
+}
+
+// SetFilterMode enables or disables filter mode.
+func (f *FilesModel) SetFilterMode(enabled bool) {
+	f.filterMode = enabled
+	if !enabled {
+		f.filterQuery = ""
+		f.applyFilter()
+		f.sortRows()
+	}
+}
+
+// IsFilterMode returns whether filter mode is active.
+func (f *FilesModel) IsFilterMode() bool {
+	return f.filterMode
+}
+
+// HandleFilterInput handles a character input in filter mode.
+func (f *FilesModel) HandleFilterInput(char string) {
+	f.filterQuery += char
+	f.applyFilter()
+	f.sortRows()
+	f.cursor = 0
+	f.offset = 0
+}
+
+// HandleFilterBackspace removes the last character from the filter query.
+func (f *FilesModel) HandleFilterBackspace() {
+	if len(f.filterQuery) > 0 {
+		f.filterQuery = f.filterQuery[:len(f.filterQuery)-1]
+		f.applyFilter()
+		f.sortRows()
+	}
+}
+
+// GetFilteredCount returns filtered/total count string.
+func (f *FilesModel) GetFilteredCount() string {
+	if f.filterQuery == "" {
+		return ""
+	}
+	return fmt.Sprintf("%d/%d", len(f.rows), len(f.allRows))
+}
+
+// sortRows sorts the rows based on current sort field and direction.
+func (f *FilesModel) sortRows() {
+	sort.SliceStable(f.rows, func(i, j int) bool {
+		var less bool
+		switch f.sortField {
+		case FileSortByEdits:
+			less = f.rows[i].edits < f.rows[j].edits
+		case FileSortByReads:
+			less = f.rows[i].reads < f.rows[j].reads
+		case FileSortBySessions:
+			less = f.rows[i].sessions < f.rows[j].sessions
+		case FileSortByName:
+			less = f.rows[i].label < f.rows[j].label
+		case FileSortByRecent:
+			less = f.rows[i].last.Before(f.rows[j].last)
+		}
+		if f.sortDesc {
+			return !less
+		}
+		return less
+	})
+}
+
+// CycleSort cycles through sort fields.
+func (f *FilesModel) CycleSort() {
+	f.sortField = (f.sortField + 1) % 5
+	f.sortRows()
+}
+
+// ToggleSortDirection toggles between ascending and descending.
+func (f *FilesModel) ToggleSortDirection() {
+	f.sortDesc = !f.sortDesc
+	f.sortRows()
+}
+
+// sortFieldName returns the display name for the sort field.
+func (f FilesModel) sortFieldName() string {
+	switch f.sortField {
+	case FileSortByReads:
+		return "Reads"
+	case FileSortBySessions:
+		return "Sessions"
+	case FileSortByName:
+		return "Name"
+	case FileSortByRecent:
+		return "Recent"
+	}
+	return "Edits"
+}
+
+// SetFocused sets the focus state.
+func (f *FilesModel) SetFocused(focused bool) {
+	f.focused = focused
+}
+
+// SetSize sets the panel dimensions.
+func (f *FilesModel) SetSize(width, height int) {
+	f.width = width
+	f.height = height
+}
+
+// CursorUp moves the cursor up.
+func (f *FilesModel) CursorUp() {
+	if f.cursor > 0 {
+		f.cursor--
+		f.ensureVisible()
+	}
+}
+
+// CursorDown moves the cursor down.
+func (f *FilesModel) CursorDown() {
+	if f.cursor < len(f.rows)-1 {
+		f.cursor++
+		f.ensureVisible()
+	}
+}
+
+// CursorUpN moves the cursor up by n items.
+func (f *FilesModel) CursorUpN(n int) {
+	f.cursor -= n
+	if f.cursor < 0 {
+		f.cursor = 0
+	}
+	f.ensureVisible()
+}
+
+// CursorDownN moves the cursor down by n items.
+func (f *FilesModel) CursorDownN(n int) {
+	f.cursor += n
+	if f.cursor >= len(f.rows) {
+		f.cursor = len(f.rows) - 1
+	}
+	if f.cursor < 0 {
+		f.cursor = 0
+	}
+	f.ensureVisible()
+}
+
+// CursorTop moves the cursor to the first row.
+func (f *FilesModel) CursorTop() {
+	f.cursor = 0
+	f.ensureVisible()
+}
+
+// CursorBottom moves the cursor to the last row.
+func (f *FilesModel) CursorBottom() {
+	f.cursor = max(0, len(f.rows)-1)
+	f.ensureVisible()
+}
+
+func (f *FilesModel) ensureVisible() {
+	visibleRows := f.visibleRows()
+	if visibleRows <= 0 {
+		return
+	}
+
+	if f.cursor < f.offset {
+		f.offset = f.cursor
+	} else if f.cursor >= f.offset+visibleRows {
+		f.offset = f.cursor - visibleRows + 1
+	}
+}
+
+func (f *FilesModel) visibleRows() int {
+	// Account for border, title, header, separator
+	if f.height > 0 {
+		return f.height - 6
+	}
+	return 10
+}
+
+// SessionsQuery returns the Sessions filter query that lists the sessions
+// that touched the selected file, or a file in the selected directory.
+func (f FilesModel) SessionsQuery() (string, bool) {
+	if f.cursor < 0 || f.cursor >= len(f.rows) {
+		return "", false
+	}
+	path := f.rows[f.cursor].path
+	if f.showDirs {
+		path = strings.TrimSuffix(path, string(filepath.Separator)) + string(filepath.Separator) + "*"
+	}
+	return `file:"` + path + `"`, true
+}
+
+// View renders the files table.
+func (f FilesModel) View() string {
+	var lines []string
+
+	// Title with panel number, view, sort indicator, and time range
+	sortIndicator := "↓"
+	if !f.sortDesc {
+		sortIndicator = "↑"
+	}
+
+	name := "Files"
+	if f.showDirs {
+		name = "Directories"
+	}
+	title := PanelTitleStyle.Render(name)
+	numKey := MutedStyle.Render(" 6")
+	sortInfo := MutedStyle.Render(fmt.Sprintf(" [%s %s]", f.sortFieldName(), sortIndicator))
+	timeRange := MutedStyle.Render(" [" + f.timeRange.String() + "]")
+
+	titleLine := title + numKey + sortInfo
+	// Show filter count if filtering
+	if f.filterQuery != "" {
+		titleLine += " " + MutedStyle.Render(f.GetFilteredCount())
+	}
+	titleLine += timeRange
+
+	// Add nav hints on the right when focused
+	if f.focused && !f.filterMode {
+		navHints := MutedStyle.Render("j/k u/i g/G")
+		leftWidth := lipgloss.Width(titleLine)
+		rightWidth := lipgloss.Width(navHints)
+		contentWidth := f.width - 4 // Account for border and padding
+		padding := contentWidth - leftWidth - rightWidth
+		if padding < 2 {
+			padding = 2
+		}
+		titleLine += strings.Repeat(" ", padding) + navHints
+	}
+
+	lines = append(lines, titleLine)
+
+	// Show filter input if in filter mode
+	if f.filterMode {
+		filterLine := "/" + f.filterQuery + "█"
+		lines = append(lines, lipgloss.NewStyle().Foreground(Primary).Render(filterLine))
+	} else {
+		lines = append(lines, "")
+	}
+
+	// Calculate column widths
+	// Account for scrollbar (2 chars: "▓ " or "░ ")
+	scrollbarW := 2
+	contentWidth := f.width - 4 - scrollbarW // Account for border, padding, and scrollbar
+	if contentWidth < 40 {
+		contentWidth = 40
+	}
+
+	// Column widths: Path (flex), Edits (6), Reads (6), Sessions (8), Last (10)
+	// Account for selection indicator (2 chars: "▶ " or "  ")
+	indicatorW := 2
+	editsW := 6
+	readsW := 6
+	sessionsW := 8
+	lastW := 10
+	pathW := contentWidth - indicatorW - editsW - readsW - sessionsW - lastW - 4 // 4 for spacing
+	if pathW < 10 {
+		pathW = 10
+	}
+
+	// Header (with indicator spacing)
+	header := fmt.Sprintf("%*s%-*s %*s %*s %*s %*s",
+		indicatorW, "",
+		pathW, "Path",
+		editsW, "Edits",
+		readsW, "Reads",
+		sessionsW, "Sessions",
+		lastW, "Last")
+	lines = append(lines, MutedStyle.Render(header))
+	lines = append(lines, MutedStyle.Render(strings.Repeat("-", contentWidth)))
+
+	visibleRows := f.visibleRows()
+
+	if len(f.rows) == 0 {
+		lines = append(lines, MutedStyle.Render("No file edits or reads found"))
+	} else {
+		endIdx := min(f.offset+visibleRows, len(f.rows))
+
+		for i := f.offset; i < endIdx; i++ {
+			row := f.rows[i]
+			isSelected := i == f.cursor && f.focused
+
+			// Selection indicator
+			indicator := "  "
+			if isSelected {
+				indicator = "▶ "
+			}
+
+			line := fmt.Sprintf("%s%-*s %*s %*s %*d %*s",
+				indicator,
+				pathW, truncateMiddle(row.label, pathW),
+				editsW, formatNumber(row.edits),
+				readsW, formatNumber(row.reads),
+				sessionsW, row.sessions,
+				lastW, util.FormatRelativeTime(row.last))
+
+			if isSelected {
+				line = HighlightStyle.Render(line)
+			}
+			lines = append(lines, line)
+		}
+	}
+
+	// Build scrollbar
+	scrollbar := RenderScrollbar(len(f.rows), visibleRows, f.offset, visibleRows)
+	scrollbarLines := strings.Split(scrollbar, "\n")
+
+	// Join content lines with scrollbar
+	content := strings.Join(lines, "\n")
+
+	// If we have a scrollbar, join it to the right of the content
+	if len(scrollbarLines) > 0 && scrollbar != "" {
+		contentLines := strings.Split(content, "\n")
+		var combined []string
+		// First lines are title, blank, header, separator (4 lines)
+		headerLines := 4
+		for i, line := range contentLines {
+			scrollChar := " "
+			if i >= headerLines && i-headerLines < len(scrollbarLines) {
+				scrollChar = scrollbarLines[i-headerLines]
+			}
+			combined = append(combined, line+" "+scrollChar)
+		}
+		content = strings.Join(combined, "\n")
+	}
+
+	// Apply border
+	style := PanelStyle(f.focused)
+	if f.width > 0 {
+		style = style.Width(f.width - 2)
+	}
+	if f.height > 0 {
+		style = style.Height(f.height - 2)
+	}
+
+	return style.Render(content)
+}
+
+// GetKeybindings returns context-specific keybindings for this panel.
+func (f FilesModel) GetKeybindings() []Keybinding {
+	if f.filterMode {
+		return []Keybinding{
+			{"esc", "clear"},
+			{"enter", "apply"},
+		}
+	}
+	view := "dirs"
+	if f.showDirs {
+		view = "files"
+	}
+	return []Keybinding{
+		{"enter", "sessions"},
+		{"v", view},
+		{"s/S", "sort"},
+		{"/", "filter"},
+	}
+}
@@ -0,0 +1,184 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/moshe-exe/lazyvibe/internal/data"
+	"github.com/moshe-exe/lazyvibe/internal/query"
+)
+
+// sessionSchema lists the query fields of the Sessions panel.
+var sessionSchema = query.Schema{
+	"project": query.FieldString,
+	"branch":  query.FieldString,
+	"model":   query.FieldString,
+	"source":  query.FieldString,
+	"id":      query.FieldString,
+	"file":    query.FieldString,
+	"msgs":    query.FieldNumber,
+	"tokens":  query.FieldNumber,
+	"cost":    query.FieldNumber,
+	"commits": query.FieldNumber,
+}
+
+// projectSchema lists the query fields of the Projects panel.
+var projectSchema = query.Schema{
+	"project":  query.FieldString,
+	"branch":   query.FieldString,
+	"model":    query.FieldString,
+	"sessions": query.FieldNumber,
+	"msgs":     query.FieldNumber,
+	"cost":     query.FieldNumber,
+	"commits":  query.FieldNumber,
+}
+
+// sessionFields describes a session to the query language. Free text
+// matches the summary, then the project name.
+func sessionFields(s data.SessionEntry) query.Fields {
+	var branches []string
+	if s.GitBranch != nil {
+		branches = []string{*s.GitBranch}
+	}
+	models := make([]string, 0, len(s.Models))
+	for model := range s.Models {
+		models = append(models, model)
+	}
+	commits := 0
+	if s.Git != nil {
+		commits = len(s.Git.Commits)
+	}
+	files := make([]string, 0, len(s.Files))
+	for path := range s.Files {
+		files = append(files, path)
+	}
+	return query.Fields{
+		Text: []string{s.Summary, s.ProjectName},
+		Strings: map[string][]string{
+			"project": {s.ProjectName, s.ProjectPath},
+			"branch":  branches,
+			"model":   models,
+			"source":  {s.SourceName(), s.Source},
+			"id":      {s.SessionID},
+			"file":    files,
+		},
+		Numbers: map[string]float64{
+			"msgs":    float64(s.MessageCount),
+			"tokens":  float64(s.Tokens.Total()),
+			"cost":    s.Cost,
+			"commits": float64(commits),
+		},
+		Time: s.Modified,
+	}
+}
+
+// projectFields describes a project to the query language.
+func projectFields(p data.ProjectSummary) query.Fields {
+	return query.Fields{
+		Text: []string{p.ProjectName},
+		Strings: map[string][]string{
+			"project": {p.ProjectName, p.ProjectPath},
+			"branch":  p.Branches,
+			"model":   p.Models,
+		},
+		Numbers: map[string]float64{
+			"sessions": float64(p.SessionCount),
+			"msgs":     float64(p.TotalMessages),
+			"cost":     p.TotalCost,
+			"commits":  float64(p.Commits),
+		},
+		Time: p.LastActivity,
+	}
+}
+
+// listFilter holds the filter prompt state shared by the list panels.
+type listFilter struct {
+	filterQuery string
+	filterMode  bool
+	query       *query.Query            // Last valid parse of filterQuery
+	queryErr    error                   // Why filterQuery failed to parse
+	results     map[string]query.Result // Match details keyed by row
+}
+
+// parseFilter parses the filter query, keeping the last valid query if
+// the current text is invalid so the list doesn't flicker while typing.
+func (f *listFilter) parseFilter(schema query.Schema) {
+	q, err := query.Parse(f.filterQuery, schema)
+	f.queryErr = err
+	if err == nil {
+		f.query = q
+	}
+	if f.filterQuery == "" {
+		f.query = nil
+	}
+}
+
+// ranked returns whether rows are ordered by free text match score.
+func (f *listFilter) ranked() bool {
+	return f.query.HasText()
+}
+
+// score returns the match score of a row.
+func (f *listFilter) score(key string) int {
+	return f.results[key].Score
+}
+
+// matchPositions returns the matched rune positions of a row's text.
+func (f *listFilter) matchPositions(key string, text int) []int {
+	res, ok := f.results[key]
+	if !ok || res.Text != text {
+		return nil
+	}
+	return res.Positions
+}
+
+// filterLine renders the filter prompt with any parse error.
+func (f *listFilter) filterLine(width int) string {
+	line := lipgloss.NewStyle().Foreground(Primary).Render("/" + f.filterQuery + "█")
+	if f.queryErr != nil {
+		msg := truncate(f.queryErr.Error(), max(width-lipgloss.Width(line)-4, 10))
+		line += "  " + ErrorStyle.Render("⚠ "+msg)
+	}
+	return line
+}
+
+// highlightPositions renders text in base style with the characters at the
+// given rune positions emphasized.
+func highlightPositions(text string, positions []int, base lipgloss.Style, selected bool) string {
+	if len(positions) == 0 {
+		return base.Render(text)
+	}
+
+	match := base.Bold(true).Underline(true)
+	if !selected {
+		match = match.Foreground(Warning)
+	}
+
+	matched := make(map[int]bool, len(positions))
+	for _, p := range positions {
+		matched[p] = true
+	}
+
+	var out, run strings.Builder
+	runMatched := false
+	flush := func() {
+		if run.Len() == 0 {
+			return
+		}
+		if runMatched {
+			out.WriteString(match.Render(run.String()))
+		} else {
+			out.WriteString(base.Render(run.String()))
+		}
+		run.Reset()
+	}
+	for i, r := range []rune(text) {
+		if matched[i] != runMatched {
+			flush()
+			runMatched = matched[i]
+		}
+		run.WriteRune(r)
+	}
+	flush()
+	return out.String()
+}
@@ -11,6 +11,8 @@ import (
 // HeaderModel represents the status header component.
 type HeaderModel struct {
 	vmStatus data.VMStatus
+	agents   int // Running Claude processes
+	problems int // Data problems found by the latest refresh
 	paused   bool
 	width    int
 }
@@ -26,6 +28,16 @@ func (h *HeaderModel) Update(vmStatus data.VMStatus, paused bool) {
 	h.paused = paused
 }
 
+// SetAgents sets the number of running Claude processes.
+func (h *HeaderModel) SetAgents(agents int) {
+	h.agents = agents
+}
+
+// SetProblems sets the number of data problems.
+func (h *HeaderModel) SetProblems(problems int) {
+	h.problems = problems
+}
+
 // SetWidth sets the header width.
 func (h *HeaderModel) SetWidth(width int) {
 	h.width = width
@@ -72,6 +84,23 @@ func (h HeaderModel) View() string {
 		parts = append(parts, MutedStyle.Render("VM: Not Running"))
 	}
 
+	// Running agents
+	if h.agents > 0 {
+		parts = append(parts, SuccessStyle.Render(fmt.Sprintf("Agents: %d running", h.agents)))
+	} else {
+		parts = append(parts, MutedStyle.Render("Agents: 0"))
+	}
+
+	// Data problems badge, details on !
+	if h.problems > 0 {
+		badge := lipgloss.NewStyle().
+			Foreground(SurfaceDark).
+			Background(Warning).
+			Bold(true).
+			Render(fmt.Sprintf(" ⚠ %d ", h.problems))
+		parts = append(parts, badge+MutedStyle.Render(" ! diagnostics"))
+	}
+
 	// Pause indicator
 	if h.paused {
 		pauseStyle := lipgloss.NewStyle().
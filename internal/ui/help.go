@@ -64,6 +64,8 @@ func (h HelpModel) View() string {
 	lines = append(lines, helpLine("2", "Jump to Activity panel"))
 	lines = append(lines, helpLine("3", "Jump to Projects panel"))
 	lines = append(lines, helpLine("4", "Jump to Sessions panel"))
+	lines = append(lines, helpLine("5", "Jump to Tools panel"))
+	lines = append(lines, helpLine("6", "Jump to Files panel"))
 	lines = append(lines, helpLine("Tab", "Next panel"))
 	lines = append(lines, helpLine("Shift+Tab", "Previous panel"))
 	lines = append(lines, "")
@@ -78,10 +80,35 @@ func (h HelpModel) View() string {
 	lines = append(lines, helpLine("G", "Go to bottom of list"))
 	lines = append(lines, "")
 
+	// Projects
+	lines = append(lines, sectionStyle.Render("Projects"))
+	lines = append(lines, helpLine("Enter", "Open project view"))
+	lines = append(lines, helpLine("Esc", "Go back"))
+	lines = append(lines, "")
+
+	// Files
+	lines = append(lines, sectionStyle.Render("Files"))
+	lines = append(lines, helpLine("Enter", "List sessions that touched it"))
+	lines = append(lines, helpLine("v", "Toggle files / directories"))
+	lines = append(lines, "")
+
+	// Sessions
+	lines = append(lines, sectionStyle.Render("Sessions"))
+	lines = append(lines, helpLine("Enter", "Open session transcript"))
+	lines = append(lines, helpLine("d", "Show session details"))
+	lines = append(lines, helpLine("y", "Copy ID, path, resume command or Markdown"))
+	lines = append(lines, helpLine("c", "Resume session in Claude"))
+	lines = append(lines, helpLine("e", "Open project in editor"))
+	lines = append(lines, helpLine("x", "Open shell in project"))
+	lines = append(lines, "")
+
 	// General
 	lines = append(lines, sectionStyle.Render("General"))
 	lines = append(lines, helpLine("r", "Force refresh all data"))
 	lines = append(lines, helpLine("p", "Pause/resume auto-refresh"))
+	lines = append(lines, helpLine("t", "Choose time range"))
+	lines = append(lines, helpLine("Ctrl+F", "Search all transcripts"))
+	lines = append(lines, helpLine("!", "Show data diagnostics"))
 	lines = append(lines, helpLine("?", "Toggle this help"))
 	lines = append(lines, helpLine("q", "Quit"))
 	lines = append(lines, "")
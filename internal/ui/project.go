@@ -0,0 +1,450 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/moshe-exe/lazyvibe/internal/data"
+	"github.com/moshe-exe/lazyvibe/internal/util"
+)
+
+// ProjectView represents the full-screen detail of a single project.
+type ProjectView struct {
+	visible  bool
+	project  *data.ProjectSummary
+	sessions []data.SessionEntry // The project's sessions, newest first
+	cursor   int
+	offset   int
+	metric   HeatmapMetric
+	width    int
+	height   int
+}
+
+// NewProjectView creates a new project view.
+func NewProjectView() ProjectView {
+	return ProjectView{}
+}
+
+// SetSize sets the view dimensions.
+func (p *ProjectView) SetSize(width, height int) {
+	p.width = width
+	p.height = height
+	p.ensureVisible()
+}
+
+// Show displays a project and its sessions.
+func (p *ProjectView) Show(project *data.ProjectSummary, sessions []data.SessionEntry) {
+	p.visible = true
+	p.cursor = 0
+	p.offset = 0
+	p.Update(project, sessions)
+}
+
+// Update replaces the project data, keeping the selected session.
+func (p *ProjectView) Update(project *data.ProjectSummary, sessions []data.SessionEntry) {
+	var selectedID string
+	if selected := p.GetSelected(); selected != nil {
+		selectedID = selected.SessionID
+	}
+
+	p.project = project
+	p.sessions = make([]data.SessionEntry, len(sessions))
+	copy(p.sessions, sessions)
+	sort.SliceStable(p.sessions, func(i, j int) bool {
+		return p.sessions[i].StartTime().After(p.sessions[j].StartTime())
+	})
+
+	for i, s := range p.sessions {
+		if s.SessionID == selectedID {
+			p.cursor = i
+			break
+		}
+	}
+	p.cursor = max(min(p.cursor, len(p.sessions)-1), 0)
+	p.ensureVisible()
+}
+
+// Hide hides the view.
+func (p *ProjectView) Hide() {
+	p.visible = false
+	p.project = nil
+	p.sessions = nil
+}
+
+// IsVisible returns whether the view is visible.
+func (p *ProjectView) IsVisible() bool {
+	return p.visible
+}
+
+// ProjectPath returns the path of the project shown.
+func (p ProjectView) ProjectPath() string {
+	if p.project == nil {
+		return ""
+	}
+	return p.project.ProjectPath
+}
+
+// GetSelected returns the selected session.
+func (p ProjectView) GetSelected() *data.SessionEntry {
+	if p.cursor >= len(p.sessions) {
+		return nil
+	}
+	return &p.sessions[p.cursor]
+}
+
+// SelectSession moves the cursor to the session with the given ID.
+func (p *ProjectView) SelectSession(sessionID string) {
+	for i, s := range p.sessions {
+		if s.SessionID == sessionID {
+			p.cursor = i
+			p.ensureVisible()
+			return
+		}
+	}
+}
+
+// CycleMetric cycles through heatmap metrics.
+func (p *ProjectView) CycleMetric() {
+	p.metric = (p.metric + 1) % 5
+}
+
+// CursorUp moves the selection up by n sessions.
+func (p *ProjectView) CursorUp(n int) {
+	p.cursor = max(p.cursor-n, 0)
+	p.ensureVisible()
+}
+
+// CursorDown moves the selection down by n sessions.
+func (p *ProjectView) CursorDown(n int) {
+	p.cursor = max(min(p.cursor+n, len(p.sessions)-1), 0)
+	p.ensureVisible()
+}
+
+// CursorTop moves the selection to the newest session.
+func (p *ProjectView) CursorTop() {
+	p.cursor = 0
+	p.ensureVisible()
+}
+
+// CursorBottom moves the selection to the oldest session.
+func (p *ProjectView) CursorBottom() {
+	p.cursor = max(len(p.sessions)-1, 0)
+	p.ensureVisible()
+}
+
+func (p *ProjectView) ensureVisible() {
+	rows := p.timelineRows()
+	if p.cursor < p.offset {
+		p.offset = p.cursor
+	} else if p.cursor >= p.offset+rows {
+		p.offset = p.cursor - rows + 1
+	}
+}
+
+// layout returns the column widths and row heights of the panels, laid
+// out like the dashboard below a one-line title.
+func (p ProjectView) layout() (leftWidth, rightWidth, topHeight, bottomHeight int) {
+	contentHeight := p.height - 1
+	leftWidth = p.width * 35 / 100
+	rightWidth = p.width - leftWidth
+	topHeight = contentHeight * 35 / 100
+	bottomHeight = contentHeight - topHeight
+	return leftWidth, rightWidth, topHeight, bottomHeight
+}
+
+// timelineRows returns the number of sessions that fit in the timeline.
+func (p ProjectView) timelineRows() int {
+	_, _, _, bottomHeight := p.layout()
+	// Border, title and blank line
+	return max(bottomHeight-4, 1)
+}
+
+// View renders the project view.
+func (p ProjectView) View() string {
+	if !p.visible || p.project == nil {
+		return ""
+	}
+
+	leftWidth, rightWidth, topHeight, bottomHeight := p.layout()
+
+	title := " " + PanelTitleStyle.Render(p.project.ProjectName) +
+		MutedStyle.Render("  "+truncateMiddle(p.project.ProjectPath, max(p.width-lipgloss.Width(p.project.ProjectName)-6, 10)))
+
+	leftColumn := lipgloss.JoinVertical(lipgloss.Left,
+		projectPanel(p.renderOverview(leftWidth-4, topHeight-2), leftWidth, topHeight, false),
+		projectPanel(p.renderActivity(leftWidth-4, bottomHeight-2), leftWidth, bottomHeight, false),
+	)
+
+	branchWidth := rightWidth * 40 / 100
+	toolWidth := rightWidth - branchWidth
+	topRight := lipgloss.JoinHorizontal(lipgloss.Top,
+		projectPanel(p.renderBranches(branchWidth-4, topHeight-2), branchWidth, topHeight, false),
+		projectPanel(p.renderTools(toolWidth-4, topHeight-2), toolWidth, topHeight, false),
+	)
+	rightColumn := lipgloss.JoinVertical(lipgloss.Left,
+		topRight,
+		projectPanel(p.renderTimeline(rightWidth-4), rightWidth, bottomHeight, true),
+	)
+
+	return title + "\n" + lipgloss.JoinHorizontal(lipgloss.Top, leftColumn, rightColumn)
+}
+
+// projectPanel draws lines in a bordered panel, clipped to its height.
+func projectPanel(lines []string, width, height int, focused bool) string {
+	if height > 2 && len(lines) > height-2 {
+		lines = lines[:height-2]
+	}
+	style := PanelStyle(focused)
+	if width > 0 {
+		style = style.Width(width - 2)
+	}
+	if height > 0 {
+		style = style.Height(height - 2)
+	}
+	return style.Render(strings.Join(lines, "\n"))
+}
+
+// renderOverview renders the project's totals.
+func (p ProjectView) renderOverview(width, height int) []string {
+	project := p.project
+	active := "—"
+	if !project.FirstActivity.IsZero() {
+		active = util.FormatTime(project.FirstActivity, "2006-01-02") + " – " +
+			util.FormatRelativeTime(project.LastActivity)
+	}
+	tokens := fmt.Sprintf("%s (%s in · %s out)", formatTokens(project.Tokens.Total()),
+		formatTokens(project.Tokens.Input+project.Tokens.CacheCreation+project.Tokens.CacheRead),
+		formatTokens(project.Tokens.Output))
+
+	lines := []string{
+		PanelTitleStyle.Render("Overview") + MutedStyle.Render(" [All Time]"),
+		"",
+		p.overviewLine("Sessions", fmt.Sprintf("%d", project.SessionCount), width),
+		p.overviewLine("Active", active, width),
+		p.overviewLine("Total time", durationText(project.TotalDuration, project.TimedSessions), width),
+		p.overviewLine("Avg length", durationText(project.AverageDuration(), project.TimedSessions), width),
+		p.overviewLine("Messages", formatNumber(project.TotalMessages), width),
+		p.overviewLine("Commits", commitsText(project), width),
+		p.overviewLine("Files", filesText(project), width),
+		p.overviewLine("Tokens", tokens, width),
+		p.overviewLine("Cost", formatCost(project.TotalCost), width),
+	}
+	if len(project.Models) > 0 {
+		lines = append(lines, p.overviewLine("Models", strings.Join(project.Models, ", "), width))
+	}
+	return lines
+}
+
+func (p ProjectView) overviewLine(label, value string, width int) string {
+	l := StatLabelStyle.Render(fmt.Sprintf("%-11s", label))
+	v := StatValueStyle.Render(truncate(value, max(width-13, 5)))
+	return "  " + l + v
+}
+
+// commitsText describes the commits made during a project's sessions.
+func commitsText(p *data.ProjectSummary) string {
+	if !p.HasGit {
+		return "— (no git repository)"
+	}
+	return fmt.Sprintf("%d (+%s -%s lines)", p.Commits, formatNumber(p.LinesAdded), formatNumber(p.LinesRemoved))
+}
+
+// filesText counts the files edited and read during a project's sessions.
+func filesText(p *data.ProjectSummary) string {
+	edited, read := 0, 0
+	for _, f := range p.Files {
+		if f.Edits > 0 {
+			edited++
+		}
+		if f.Reads > 0 {
+			read++
+		}
+	}
+	return fmt.Sprintf("%d edited · %d read", edited, read)
+}
+
+// durationText formats a duration summed over sessions, "—" if no
+// session had known times.
+func durationText(d time.Duration, sessions int) string {
+	if sessions == 0 {
+		return "—"
+	}
+	return data.FormatDuration(d)
+}
+
+// renderActivity renders the project's heatmap and a 30-day sparkline.
+func (p ProjectView) renderActivity(width, height int) []string {
+	lines := []string{
+		PanelTitleStyle.Render("Activity") + MutedStyle.Render(" ["+p.metric.Name()+"]"),
+		"",
+	}
+
+	activityMap := make(map[string]int)
+	maxVal := 0
+	for _, day := range p.project.Daily {
+		val := p.metric.value(day)
+		activityMap[day.Date] = val
+		maxVal = max(maxVal, val)
+	}
+
+	// Title, blank, day labels, legend, sparkline and their spacing
+	weeks := min(max(height-10, 4), 12)
+	lines = append(lines, heatmapGrid(activityMap, maxVal, weeks, time.Now())...)
+	lines = append(lines, "", heatmapLegend(), "")
+
+	// Last 30 days including quiet ones
+	days := min(30, max(width-5, 1))
+	values := make([]int, days)
+	total := 0
+	today := time.Now()
+	for i := range values {
+		date := today.AddDate(0, 0, i-days+1).Format("2006-01-02")
+		values[i] = activityMap[date]
+		total += values[i]
+	}
+	lines = append(lines,
+		"     "+blockSparkline(values),
+		"     "+MutedStyle.Render(fmt.Sprintf("%s in the last %d days", p.metric.format(total), days)))
+	return lines
+}
+
+// renderBranches renders the branches with the most sessions.
+func (p ProjectView) renderBranches(width, height int) []string {
+	lines := []string{PanelTitleStyle.Render("Branches"), ""}
+	branches := p.project.TopBranches(max(height-2, 1))
+	if len(branches) == 0 {
+		return append(lines, MutedStyle.Render("No git branches"))
+	}
+
+	countW := 5
+	nameW := max(width-countW-3, 8)
+	for _, b := range branches {
+		lines = append(lines, fmt.Sprintf("  %-*s %*d",
+			nameW, truncate(b.Branch, nameW), countW, b.Sessions))
+	}
+	return lines
+}
+
+// renderTools renders the project's most-used tools.
+func (p ProjectView) renderTools(width, height int) []string {
+	lines := []string{PanelTitleStyle.Render("Tools")}
+	tools := p.project.TopTools(max(height-2, 1))
+	if len(tools) == 0 {
+		return append(lines, "", MutedStyle.Render("No tool calls"))
+	}
+
+	callsW, errorsW, sessionsW := 7, 6, 8
+	toolW := max(width-callsW-errorsW-sessionsW-5, 8)
+	lines = append(lines, MutedStyle.Render(fmt.Sprintf("  %-*s %*s %*s %*s",
+		toolW, "Tool", callsW, "Calls", errorsW, "Errors", sessionsW, "Sessions")))
+	for _, tool := range tools {
+		lines = append(lines, fmt.Sprintf("  %-*s %*s %*s %*d",
+			toolW, truncate(tool.Name, toolW),
+			callsW, formatNumber(tool.Calls),
+			errorsW, formatNumber(tool.Errors),
+			sessionsW, tool.Sessions))
+	}
+	return lines
+}
+
+// renderTimeline renders the project's sessions by start time, with a
+// bar showing each session's length.
+func (p ProjectView) renderTimeline(width int) []string {
+	lines := []string{
+		PanelTitleStyle.Render("Sessions") + MutedStyle.Render(fmt.Sprintf(" [%d]", len(p.sessions))),
+		"",
+	}
+	if len(p.sessions) == 0 {
+		return append(lines, MutedStyle.Render("No sessions found"))
+	}
+
+	var longest time.Duration
+	for _, s := range p.sessions {
+		if s.Duration() > longest {
+			longest = s.Duration()
+		}
+	}
+
+	const barW = 10
+	rows := p.timelineRows()
+	end := min(p.offset+rows, len(p.sessions))
+	prevDate := ""
+	if p.offset > 0 {
+		prevDate = util.FormatTime(p.sessions[p.offset-1].StartTime(), "Mon Jan 02")
+	}
+	for i := p.offset; i < end; i++ {
+		s := p.sessions[i]
+		selected := i == p.cursor
+
+		indicator := "  "
+		if selected {
+			indicator = "▶ "
+		}
+		if s.IsLive() {
+			indicator = indicator[:len(indicator)-1] + lipgloss.NewStyle().Foreground(Success).Bold(true).Render("●")
+		}
+
+		// The date is shown on the first session of each day
+		started := s.StartTime()
+		date := util.FormatTime(started, "Mon Jan 02")
+		dateCol := date
+		if date == prevDate {
+			dateCol = ""
+		}
+		prevDate = date
+
+		bar := 0
+		if longest > 0 {
+			bar = int(int64(s.Duration()) * barW / int64(longest))
+		}
+		if s.Duration() > 0 {
+			bar = max(bar, 1)
+		}
+
+		// Pad by display width, as unknown times show as "—"
+		left := lipgloss.NewStyle().Width(10)
+		right := lipgloss.NewStyle().Align(lipgloss.Right)
+		prefix := left.Render(dateCol) + " " +
+			right.Width(5).Render(util.FormatTime(started, "15:04")) + " " +
+			right.Width(6).Render(s.FormatDuration()) + " "
+		suffix := fmt.Sprintf(" %5d msgs  ", s.MessageCount)
+		summary := ""
+		// Leave room for the indicator and scrollbar
+		if avail := width - lipgloss.Width(prefix) - barW - len(suffix) - 4; avail > 0 {
+			summary = truncate(s.Summary, avail)
+		}
+
+		bars := lipgloss.NewStyle().Foreground(Primary).Render(strings.Repeat("█", bar)) +
+			MutedStyle.Render(strings.Repeat("·", barW-bar))
+		if selected {
+			lines = append(lines, indicator+HighlightStyle.Render(prefix)+bars+HighlightStyle.Render(suffix+summary))
+		} else {
+			lines = append(lines, indicator+prefix+bars+MutedStyle.Render(suffix)+summary)
+		}
+	}
+
+	// Scrollbar to the right of the rows, below the title and blank line
+	if scrollbar := RenderScrollbar(len(p.sessions), rows, p.offset, rows); scrollbar != "" {
+		for i, char := range strings.Split(scrollbar, "\n") {
+			if 2+i < len(lines) {
+				row := lines[2+i]
+				lines[2+i] = row + strings.Repeat(" ", max(width-1-lipgloss.Width(row), 1)) + char
+			}
+		}
+	}
+	return lines
+}
+
+// GetKeybindings returns context-specific keybindings for this view.
+func (p ProjectView) GetKeybindings() []Keybinding {
+	return []Keybinding{
+		{"esc", "back"},
+		{"enter", "transcript"},
+		{"d", "details"},
+		{"c", "resume"},
+		{"m", "metric"},
+	}
+}
@@ -7,19 +7,10 @@ import (
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/moshe-exe/lazyvibe/internal/data"
+	"github.com/moshe-exe/lazyvibe/internal/query"
 	"github.com/moshe-exe/lazyvibe/internal/util"
 )
 
-// ProjectSortField represents the field to sort by.
-type ProjectSortField int
-
-const (
-	ProjectSortByActivity ProjectSortField = iota
-	ProjectSortByName
-	ProjectSortBySessions
-	ProjectSortByMessages
-)
-
 // ProjectsModel represents the projects table component.
 type ProjectsModel struct {
 	projects    []data.ProjectSummary
@@ -29,18 +20,18 @@ type ProjectsModel struct {
 	focused     bool
 	width       int
 	height      int
-	sortField   ProjectSortField
+	sortField   data.ProjectSortField
 	sortDesc    bool
-	filterQuery string
-	filterMode  bool
 	timeRange   data.TimeRange
+
+	listFilter
 }
 
 // NewProjectsModel creates a new projects model.
 func NewProjectsModel() ProjectsModel {
 	return ProjectsModel{
-		sortField: ProjectSortByActivity, // Default sort by last activity
-		sortDesc:  true,                  // Most recent first
+		sortField: data.ProjectSortByActivity, // Default sort by last activity
+		sortDesc:  true,                       // Most recent first
 	}
 }
 
@@ -60,17 +51,19 @@ func (p *ProjectsModel) Update(projects []data.ProjectSummary, timeRange data.Ti
 
 // applyFilter filters projects based on the current filter query.
 func (p *ProjectsModel) applyFilter() {
-	if p.filterQuery == "" {
+	p.results = nil
+	if p.query.IsEmpty() {
 		p.projects = make([]data.ProjectSummary, len(p.allProjects))
 		copy(p.projects, p.allProjects)
 		return
 	}
 
-	query := strings.ToLower(p.filterQuery)
+	p.results = make(map[string]query.Result)
 	var filtered []data.ProjectSummary
 	for _, project := range p.allProjects {
-		if strings.Contains(strings.ToLower(project.ProjectName), query) {
+		if res, ok := p.query.Match(projectFields(project)); ok {
 			filtered = append(filtered, project)
+			p.results[project.ProjectPath] = res
 		}
 	}
 	p.projects = filtered
@@ -81,6 +74,7 @@ func (p *ProjectsModel) SetFilterMode(enabled bool) {
 	p.filterMode = enabled
 	if !enabled {
 		p.filterQuery = ""
+		p.parseFilter(projectSchema)
 		p.applyFilter()
 		p.sortProjects()
 	}
@@ -94,6 +88,7 @@ func (p *ProjectsModel) IsFilterMode() bool {
 // HandleFilterInput handles a character input in filter mode.
 func (p *ProjectsModel) HandleFilterInput(char string) {
 	p.filterQuery += char
+	p.parseFilter(projectSchema)
 	p.applyFilter()
 	p.sortProjects()
 	p.cursor = 0
@@ -104,6 +99,7 @@ func (p *ProjectsModel) HandleFilterInput(char string) {
 func (p *ProjectsModel) HandleFilterBackspace() {
 	if len(p.filterQuery) > 0 {
 		p.filterQuery = p.filterQuery[:len(p.filterQuery)-1]
+		p.parseFilter(projectSchema)
 		p.applyFilter()
 		p.sortProjects()
 	}
@@ -123,29 +119,23 @@ func (p *ProjectsModel) GetFilteredCount() string {
 }
 
 // sortProjects sorts the projects based on current sort field and direction.
+// Free text in the filter ranks the best matches first.
 func (p *ProjectsModel) sortProjects() {
+	ranked := p.ranked()
 	sort.Slice(p.projects, func(i, j int) bool {
-		var less bool
-		switch p.sortField {
-		case ProjectSortByName:
-			less = p.projects[i].ProjectName < p.projects[j].ProjectName
-		case ProjectSortBySessions:
-			less = p.projects[i].SessionCount < p.projects[j].SessionCount
-		case ProjectSortByMessages:
-			less = p.projects[i].TotalMessages < p.projects[j].TotalMessages
-		case ProjectSortByActivity:
-			less = p.projects[i].LastActivity.Before(p.projects[j].LastActivity)
-		}
-		if p.sortDesc {
-			return !less
+		if ranked {
+			si, sj := p.score(p.projects[i].ProjectPath), p.score(p.projects[j].ProjectPath)
+			if si != sj {
+				return si > sj
+			}
 		}
-		return less
+		return data.ProjectLess(&p.projects[i], &p.projects[j], p.sortField, p.sortDesc)
 	})
 }
 
 // CycleSort cycles through sort fields.
 func (p *ProjectsModel) CycleSort() {
-	p.sortField = (p.sortField + 1) % 4
+	p.sortField = p.sortField.Next()
 	p.sortProjects()
 }
 
@@ -203,6 +193,18 @@ func (p *ProjectsModel) CursorDownN(n int) {
 	p.ensureVisible()
 }
 
+// CursorTop moves the cursor to the first row.
+func (p *ProjectsModel) CursorTop() {
+	p.cursor = 0
+	p.ensureVisible()
+}
+
+// CursorBottom moves the cursor to the last row.
+func (p *ProjectsModel) CursorBottom() {
+	p.cursor = max(0, len(p.projects)-1)
+	p.ensureVisible()
+}
+
 func (p *ProjectsModel) ensureVisible() {
 	visibleRows := p.visibleRows()
 	if visibleRows <= 0 {
@@ -226,17 +228,10 @@ func (p *ProjectsModel) visibleRows() int {
 
 // sortFieldName returns the display name for the sort field.
 func (p ProjectsModel) sortFieldName() string {
-	switch p.sortField {
-	case ProjectSortByName:
-		return "Name"
-	case ProjectSortBySessions:
-		return "Sessions"
-	case ProjectSortByMessages:
-		return "Messages"
-	case ProjectSortByActivity:
-		return "Activity"
-	}
-	return "Activity"
+	if p.ranked() {
+		return "Match"
+	}
+	return p.sortField.String()
 }
 
 // View renders the projects table.
@@ -263,7 +258,7 @@ func (p ProjectsModel) View() string {
 
 	// Add nav hints on the right when focused
 	if p.focused && !p.filterMode {
-		navHints := MutedStyle.Render("j/k u/i")
+		navHints := MutedStyle.Render("j/k u/i g/G")
 		leftWidth := lipgloss.Width(titleLine)
 		rightWidth := lipgloss.Width(navHints)
 		contentWidth := p.width - 4 // Account for border and padding
@@ -278,8 +273,7 @@ func (p ProjectsModel) View() string {
 
 	// Show filter input if in filter mode
 	if p.filterMode {
-		filterLine := "/" + p.filterQuery + "█"
-		lines = append(lines, lipgloss.NewStyle().Foreground(Primary).Render(filterLine))
+		lines = append(lines, p.filterLine(p.width-4))
 	} else {
 		lines = append(lines, "")
 	}
@@ -292,23 +286,27 @@ func (p ProjectsModel) View() string {
 		contentWidth = 40
 	}
 
-	// Column widths: Project (flex), Sessions (8), Messages (10), Last Active (12)
+	// Column widths: Project (flex), Sessions (8), Messages (10), Commits (7), Cost (9), Last Active (12)
 	// Account for selection indicator (2 chars: "▶ " or "  ")
 	indicatorW := 2
 	sessionsW := 8
 	messagesW := 10
+	commitsW := 7
+	costW := 9
 	lastActiveW := 12
-	projectW := contentWidth - indicatorW - sessionsW - messagesW - lastActiveW - 6 // 6 for spacing
+	projectW := contentWidth - indicatorW - sessionsW - messagesW - commitsW - costW - lastActiveW - 8 // 8 for spacing
 	if projectW < 10 {
 		projectW = 10
 	}
 
 	// Header (with indicator spacing)
-	header := fmt.Sprintf("%*s%-*s %*s %*s %*s",
+	header := fmt.Sprintf("%*s%-*s %*s %*s %*s %*s %*s",
 		indicatorW, "",
 		projectW, "Project",
 		sessionsW, "Sessions",
 		messagesW, "Messages",
+		commitsW, "Commits",
+		costW, "Cost",
 		lastActiveW, "Last Active")
 	lines = append(lines, MutedStyle.Render(header))
 	lines = append(lines, MutedStyle.Render(strings.Repeat("-", contentWidth)))
@@ -333,16 +331,26 @@ func (p ProjectsModel) View() string {
 			name := truncate(project.ProjectName, projectW)
 			lastActive := util.FormatRelativeTime(project.LastActivity)
 
-			row := fmt.Sprintf("%s%-*s %*d %*s %*s",
-				indicator,
-				projectW, name,
+			// Projects outside a git repository have no commits to count
+			commits := "-"
+			if project.HasGit {
+				commits = formatNumber(project.Commits)
+			}
+
+			stats := fmt.Sprintf(" %*d %*s %*s %*s %*s",
 				sessionsW, project.SessionCount,
 				messagesW, formatNumber(project.TotalMessages),
+				commitsW, commits,
+				costW, formatCost(project.TotalCost),
 				lastActiveW, lastActive)
 
+			base := lipgloss.NewStyle()
 			if isSelected {
-				row = HighlightStyle.Render(row)
+				base = HighlightStyle
 			}
+			row := base.Render(indicator) +
+				highlightPositions(name, p.matchPositions(project.ProjectPath, 0), base, isSelected) +
+				base.Render(strings.Repeat(" ", max(projectW-lipgloss.Width(name), 0))+stats)
 			lines = append(lines, row)
 		}
 	}
@@ -415,6 +423,14 @@ func (p ProjectsModel) GetScrollInfo() string {
 		fmt.Sprintf("[%d/%d]", p.cursor+1, len(p.projects)))
 }
 
+// GetSelected returns the currently selected project.
+func (p ProjectsModel) GetSelected() *data.ProjectSummary {
+	if len(p.projects) == 0 || p.cursor >= len(p.projects) {
+		return nil
+	}
+	return &p.projects[p.cursor]
+}
+
 // GetKeybindings returns context-specific keybindings for this panel.
 func (p ProjectsModel) GetKeybindings() []Keybinding {
 	if p.filterMode {
@@ -426,5 +442,7 @@ func (p ProjectsModel) GetKeybindings() []Keybinding {
 	return []Keybinding{
 		{"s/S", "sort"},
 		{"/", "filter"},
+		{"enter", "open"},
+		{"y", "copy"},
 	}
 }
@@ -0,0 +1,263 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/moshe-exe/lazyvibe/internal/data"
+	"github.com/moshe-exe/lazyvibe/internal/util"
+)
+
+// searchLimit caps the number of sessions listed by a search.
+const searchLimit = 200
+
+// SearchModal represents the full-text search prompt and its results.
+type SearchModal struct {
+	visible bool
+	query   string
+	seq     int  // Incremented on every query change so stale results are dropped
+	pending bool // A search for the current query is running
+	results []data.SearchResult
+	cursor  int
+	offset  int
+	width   int
+	height  int
+}
+
+// NewSearchModal creates a new search modal.
+func NewSearchModal() SearchModal {
+	return SearchModal{}
+}
+
+// SetSize sets the modal dimensions.
+func (s *SearchModal) SetSize(width, height int) {
+	s.width = width
+	s.height = height
+}
+
+// Show displays the modal, keeping the previous query and results.
+func (s *SearchModal) Show() {
+	s.visible = true
+}
+
+// Hide hides the modal.
+func (s *SearchModal) Hide() {
+	s.visible = false
+}
+
+// IsVisible returns whether the modal is visible.
+func (s *SearchModal) IsVisible() bool {
+	return s.visible
+}
+
+// Query returns the current query and its sequence number.
+func (s *SearchModal) Query() (string, int) {
+	return s.query, s.seq
+}
+
+// HandleInput appends a character to the query. It returns whether a
+// search should run.
+func (s *SearchModal) HandleInput(char string) bool {
+	s.query += char
+	return s.queryChanged()
+}
+
+// HandleBackspace removes the last character from the query. It returns
+// whether a search should run.
+func (s *SearchModal) HandleBackspace() bool {
+	if len(s.query) == 0 {
+		return false
+	}
+	s.query = s.query[:len(s.query)-1]
+	return s.queryChanged()
+}
+
+// queryChanged invalidates running searches and clears the results of
+// an empty query.
+func (s *SearchModal) queryChanged() bool {
+	s.seq++
+	if strings.TrimSpace(s.query) == "" {
+		s.pending = false
+		s.setResults(nil)
+		return false
+	}
+	s.pending = true
+	return true
+}
+
+// SetResults shows the results of the search numbered seq, unless the
+// query changed since it started.
+func (s *SearchModal) SetResults(seq int, results []data.SearchResult) {
+	if seq != s.seq {
+		return
+	}
+	s.pending = false
+	s.setResults(results)
+}
+
+func (s *SearchModal) setResults(results []data.SearchResult) {
+	s.results = results
+	s.cursor = 0
+	s.offset = 0
+}
+
+// GetSelected returns the selected result.
+func (s SearchModal) GetSelected() *data.SearchResult {
+	if s.cursor >= len(s.results) {
+		return nil
+	}
+	return &s.results[s.cursor]
+}
+
+// CursorUp moves the selection up by n results.
+func (s *SearchModal) CursorUp(n int) {
+	s.cursor = max(s.cursor-n, 0)
+	s.ensureVisible()
+}
+
+// CursorDown moves the selection down by n results.
+func (s *SearchModal) CursorDown(n int) {
+	s.cursor = max(min(s.cursor+n, len(s.results)-1), 0)
+	s.ensureVisible()
+}
+
+func (s *SearchModal) ensureVisible() {
+	rows := s.visibleRows()
+	if s.cursor < s.offset {
+		s.offset = s.cursor
+	} else if s.cursor >= s.offset+rows {
+		s.offset = s.cursor - rows + 1
+	}
+}
+
+// modalWidth returns the width of the modal box.
+func (s SearchModal) modalWidth() int {
+	return max(s.width*80/100, 50)
+}
+
+// visibleRows returns the number of results that fit.
+func (s SearchModal) visibleRows() int {
+	// Border, padding, title, prompt, separator and hints; 2 lines per result
+	return max((s.height-12)/2, 1)
+}
+
+// View renders the search modal.
+func (s SearchModal) View() string {
+	if !s.visible {
+		return ""
+	}
+
+	modalWidth := s.modalWidth()
+	textWidth := modalWidth - 8
+
+	title := PanelTitleStyle.Render("Search")
+	switch {
+	case s.pending:
+		title += MutedStyle.Render(" searching…")
+	case s.query != "":
+		title += MutedStyle.Render(fmt.Sprintf(" %d sessions", len(s.results)))
+	}
+
+	lines := []string{
+		title,
+		lipgloss.NewStyle().Foreground(Primary).Render("> " + s.query + "█"),
+		MutedStyle.Render(strings.Repeat("-", modalWidth-4)),
+	}
+
+	rows := s.visibleRows()
+	switch {
+	case strings.TrimSpace(s.query) == "":
+		lines = append(lines, MutedStyle.Render("Type to search prompts and responses in every session"))
+	case len(s.results) == 0 && !s.pending:
+		lines = append(lines, MutedStyle.Render("No matches"))
+	default:
+		end := min(s.offset+rows, len(s.results))
+		for i := s.offset; i < end; i++ {
+			lines = append(lines, s.resultLines(s.results[i], i == s.cursor, textWidth)...)
+		}
+	}
+	for len(lines) < rows*2+3 {
+		lines = append(lines, "")
+	}
+
+	lines = append(lines, "", MutedStyle.Render("↑/↓ select  enter go to session  esc close"))
+
+	modal := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(Primary).
+		Padding(1, 2).
+		Width(modalWidth).
+		Render(strings.Join(lines, "\n"))
+
+	// Center the modal
+	paddingLeft := max((s.width-modalWidth)/2, 0)
+	paddingTop := max((s.height-lipgloss.Height(modal))/2, 0)
+
+	leftPadding := strings.Repeat(" ", paddingLeft)
+	modalLines := strings.Split(modal, "\n")
+	for i, line := range modalLines {
+		modalLines[i] = leftPadding + line
+	}
+	return strings.Repeat("\n", paddingTop) + strings.Join(modalLines, "\n")
+}
+
+// resultLines renders a result as a session line and a snippet line.
+func (s SearchModal) resultLines(r data.SearchResult, selected bool, width int) []string {
+	indicator := "  "
+	if selected {
+		indicator = "▶ "
+	}
+	when := util.FormatRelativeTimeShort(r.Timestamp)
+	hits := ""
+	if r.Hits > 1 {
+		hits = fmt.Sprintf(" (%d hits)", r.Hits)
+	}
+	project := truncate(r.ProjectName, 18)
+	prefix := fmt.Sprintf("%s%-4s %s: ", indicator, when, project)
+	summary := truncate(r.Summary, max(width-lipgloss.Width(prefix)-len(hits), 10))
+
+	header := prefix + summary + hits
+	if selected {
+		header = HighlightStyle.Render(header)
+	}
+
+	role := "you"
+	if r.Role == "assistant" {
+		role = "claude"
+	}
+	rolePrefix := "    " + role + ": "
+	snippet := MutedStyle.Render(rolePrefix) +
+		highlightRanges(r.Snippet, r.Matches, width-len(rolePrefix), lipgloss.NewStyle().Foreground(Text))
+	return []string{header, snippet}
+}
+
+// highlightRanges renders up to width runes of text in the base style,
+// with the given byte ranges emphasized.
+func highlightRanges(text string, ranges [][2]int, width int, base lipgloss.Style) string {
+	// Cut to width on a rune boundary
+	if width > 0 {
+		runes := 0
+		for i := range text {
+			if runes == width {
+				text = text[:i]
+				break
+			}
+			runes++
+		}
+	}
+
+	matchStyle := lipgloss.NewStyle().Background(Warning).Foreground(SurfaceDark)
+	var sb strings.Builder
+	pos := 0
+	for _, r := range ranges {
+		if r[0] < pos || r[1] > len(text) {
+			continue
+		}
+		sb.WriteString(base.Render(text[pos:r[0]]))
+		sb.WriteString(matchStyle.Render(text[r[0]:r[1]]))
+		pos = r[1]
+	}
+	sb.WriteString(base.Render(text[pos:]))
+	return sb.String()
+}
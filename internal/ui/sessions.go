@@ -7,18 +7,10 @@ import (
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/moshe-exe/lazyvibe/internal/data"
+	"github.com/moshe-exe/lazyvibe/internal/query"
 	"github.com/moshe-exe/lazyvibe/internal/util"
 )
 
-// SessionSortField represents the field to sort sessions by.
-type SessionSortField int
-
-const (
-	SessionSortByTime SessionSortField = iota
-	SessionSortByMessages
-	SessionSortByProject
-)
-
 // SessionsModel represents the recent sessions component.
 type SessionsModel struct {
 	sessions    []data.SessionEntry
@@ -28,61 +20,74 @@ type SessionsModel struct {
 	focused     bool
 	width       int
 	height      int
-	sortField   SessionSortField
+	sortField   data.SessionSortField
 	sortDesc    bool
-	filterQuery string
-	filterMode  bool
 	timeRange   data.TimeRange
+	pulse       bool // Alternates to animate live indicators
+	multiSource bool // Sessions come from more than one data directory
+
+	listFilter
 }
 
 // NewSessionsModel creates a new sessions model.
 func NewSessionsModel() SessionsModel {
 	return SessionsModel{
-		sortField: SessionSortByTime,
+		sortField: data.SessionSortByTime,
 		sortDesc:  true, // Most recent first
 	}
 }
 
-// Update updates the sessions data.
+// Update updates the sessions data. Every session in the time range is
+// kept; only the rows scrolled into view are rendered. The selected
+// session stays selected if it is still listed.
 func (s *SessionsModel) Update(sessions []data.SessionEntry, timeRange data.TimeRange) {
-	// Copy and keep only last 20
-	sorted := make([]data.SessionEntry, len(sessions))
-	copy(sorted, sessions)
-
-	// Initial sort by time to get most recent
-	sort.Slice(sorted, func(i, j int) bool {
-		return sorted[i].Modified.After(sorted[j].Modified)
-	})
-
-	if len(sorted) > 20 {
-		sorted = sorted[:20]
+	var selectedID string
+	if selected := s.GetSelected(); selected != nil {
+		selectedID = selected.SessionID
 	}
 
-	s.allSessions = sorted
+	s.allSessions = make([]data.SessionEntry, len(sessions))
+	copy(s.allSessions, sessions)
 	s.timeRange = timeRange
+	s.multiSource = false
+	for _, session := range s.allSessions {
+		if session.Source != s.allSessions[0].Source {
+			s.multiSource = true
+			break
+		}
+	}
 	s.applyFilter()
 	s.sortSessions()
 
+	for i := range s.sessions {
+		if s.sessions[i].SessionID == selectedID {
+			s.cursor = i
+			break
+		}
+	}
+
 	// Reset cursor if out of bounds
 	if s.cursor >= len(s.sessions) {
 		s.cursor = max(0, len(s.sessions)-1)
 	}
+	s.ensureVisible()
 }
 
 // applyFilter filters sessions based on the current filter query.
 func (s *SessionsModel) applyFilter() {
-	if s.filterQuery == "" {
+	s.results = nil
+	if s.query.IsEmpty() {
 		s.sessions = make([]data.SessionEntry, len(s.allSessions))
 		copy(s.sessions, s.allSessions)
 		return
 	}
 
-	query := strings.ToLower(s.filterQuery)
+	s.results = make(map[string]query.Result)
 	var filtered []data.SessionEntry
 	for _, session := range s.allSessions {
-		if strings.Contains(strings.ToLower(session.Summary), query) ||
-			strings.Contains(strings.ToLower(session.ProjectName), query) {
+		if res, ok := s.query.Match(sessionFields(session)); ok {
 			filtered = append(filtered, session)
+			s.results[session.SessionID] = res
 		}
 	}
 	s.sessions = filtered
@@ -93,6 +98,7 @@ func (s *SessionsModel) SetFilterMode(enabled bool) {
 	s.filterMode = enabled
 	if !enabled {
 		s.filterQuery = ""
+		s.parseFilter(sessionSchema)
 		s.applyFilter()
 		s.sortSessions()
 	}
@@ -106,6 +112,7 @@ func (s *SessionsModel) IsFilterMode() bool {
 // HandleFilterInput handles a character input in filter mode.
 func (s *SessionsModel) HandleFilterInput(char string) {
 	s.filterQuery += char
+	s.parseFilter(sessionSchema)
 	s.applyFilter()
 	s.sortSessions()
 	s.cursor = 0
@@ -116,11 +123,23 @@ func (s *SessionsModel) HandleFilterInput(char string) {
 func (s *SessionsModel) HandleFilterBackspace() {
 	if len(s.filterQuery) > 0 {
 		s.filterQuery = s.filterQuery[:len(s.filterQuery)-1]
+		s.parseFilter(sessionSchema)
 		s.applyFilter()
 		s.sortSessions()
 	}
 }
 
+// SetFilterQuery replaces the filter with an applied query.
+func (s *SessionsModel) SetFilterQuery(q string) {
+	s.filterMode = false
+	s.filterQuery = q
+	s.parseFilter(sessionSchema)
+	s.applyFilter()
+	s.sortSessions()
+	s.cursor = 0
+	s.offset = 0
+}
+
 // GetFilterQuery returns the current filter query.
 func (s *SessionsModel) GetFilterQuery() string {
 	return s.filterQuery
@@ -135,27 +154,24 @@ func (s *SessionsModel) GetFilteredCount() string {
 }
 
 // sortSessions sorts the sessions based on current sort field and direction.
+// Sessions with an unknown time stay last when sorting by time. Free text
+// in the filter ranks the best matches first.
 func (s *SessionsModel) sortSessions() {
+	ranked := s.ranked()
 	sort.Slice(s.sessions, func(i, j int) bool {
-		var less bool
-		switch s.sortField {
-		case SessionSortByTime:
-			less = s.sessions[i].Modified.Before(s.sessions[j].Modified)
-		case SessionSortByMessages:
-			less = s.sessions[i].MessageCount < s.sessions[j].MessageCount
-		case SessionSortByProject:
-			less = s.sessions[i].ProjectName < s.sessions[j].ProjectName
-		}
-		if s.sortDesc {
-			return !less
+		if ranked {
+			si, sj := s.score(s.sessions[i].SessionID), s.score(s.sessions[j].SessionID)
+			if si != sj {
+				return si > sj
+			}
 		}
-		return less
+		return data.SessionLess(&s.sessions[i], &s.sessions[j], s.sortField, s.sortDesc)
 	})
 }
 
 // CycleSort cycles through sort fields.
 func (s *SessionsModel) CycleSort() {
-	s.sortField = (s.sortField + 1) % 3
+	s.sortField = s.sortField.Next()
 	s.sortSessions()
 }
 
@@ -167,15 +183,25 @@ func (s *SessionsModel) ToggleSortDirection() {
 
 // sortFieldName returns the display name for the sort field.
 func (s SessionsModel) sortFieldName() string {
-	switch s.sortField {
-	case SessionSortByTime:
-		return "Time"
-	case SessionSortByMessages:
-		return "Messages"
-	case SessionSortByProject:
-		return "Project"
+	if s.ranked() {
+		return "Match"
 	}
-	return "Time"
+	return s.sortField.String()
+}
+
+// TogglePulse advances the live indicator animation.
+func (s *SessionsModel) TogglePulse() {
+	s.pulse = !s.pulse
+}
+
+// HasLive returns whether any listed session is live.
+func (s SessionsModel) HasLive() bool {
+	for i := range s.allSessions {
+		if s.allSessions[i].IsLive() {
+			return true
+		}
+	}
+	return false
 }
 
 // SetFocused sets the focus state.
@@ -226,6 +252,31 @@ func (s *SessionsModel) CursorDownN(n int) {
 	s.ensureVisible()
 }
 
+// CursorTop moves the cursor to the first session.
+func (s *SessionsModel) CursorTop() {
+	s.cursor = 0
+	s.ensureVisible()
+}
+
+// CursorBottom moves the cursor to the last session.
+func (s *SessionsModel) CursorBottom() {
+	s.cursor = max(0, len(s.sessions)-1)
+	s.ensureVisible()
+}
+
+// SelectSession moves the cursor to the session with the given ID and
+// returns whether it is listed.
+func (s *SessionsModel) SelectSession(sessionID string) bool {
+	for i := range s.sessions {
+		if s.sessions[i].SessionID == sessionID {
+			s.cursor = i
+			s.ensureVisible()
+			return true
+		}
+	}
+	return false
+}
+
 func (s *SessionsModel) ensureVisible() {
 	visibleRows := s.visibleRows()
 	if visibleRows <= 0 {
@@ -237,6 +288,10 @@ func (s *SessionsModel) ensureVisible() {
 	} else if s.cursor >= s.offset+visibleRows {
 		s.offset = s.cursor - visibleRows + 1
 	}
+	// Don't leave blank rows below the last session
+	if maxOffset := max(0, len(s.sessions)-visibleRows); s.offset > maxOffset {
+		s.offset = maxOffset
+	}
 }
 
 func (s *SessionsModel) visibleRows() int {
@@ -271,7 +326,7 @@ func (s SessionsModel) View() string {
 
 	// Add nav hints on the right when focused
 	if s.focused && !s.filterMode {
-		navHints := MutedStyle.Render("j/k u/i")
+		navHints := MutedStyle.Render("j/k u/i g/G")
 		leftWidth := lipgloss.Width(titleLine)
 		rightWidth := lipgloss.Width(navHints)
 		contentWidth := s.width - 4 // Account for border and padding
@@ -286,8 +341,7 @@ func (s SessionsModel) View() string {
 
 	// Show filter input if in filter mode
 	if s.filterMode {
-		filterLine := "/" + s.filterQuery + "█"
-		lines = append(lines, lipgloss.NewStyle().Foreground(Primary).Render(filterLine))
+		lines = append(lines, s.filterLine(s.width-4))
 	} else {
 		lines = append(lines, "")
 	}
@@ -333,17 +387,37 @@ func (s SessionsModel) View() string {
 				branch = fmt.Sprintf(" [%s]", truncate(*session.GitBranch, 15))
 			}
 
-			line1 := fmt.Sprintf("%s%s: %s%s", indicator, relativeTime, summary, branch)
+			// Live sessions show a pulsing dot in place of the indicator's padding
+			if session.IsLive() {
+				dot := lipgloss.NewStyle().Foreground(Success).Bold(true).Render("●")
+				if s.pulse {
+					dot = lipgloss.NewStyle().Foreground(Success).Faint(true).Render("○")
+				}
+				indicator = indicator[:len(indicator)-1] + dot
+			}
 
 			// Second line: project name, message count, duration (indented to align with content)
-			line2 := fmt.Sprintf("    %s | %d msgs | %s", truncate(session.ProjectName, 18), session.MessageCount, session.FormatDuration())
+			details := fmt.Sprintf(" | %d msgs | %s", session.MessageCount, session.FormatDuration())
+			if session.Git != nil && len(session.Git.Commits) > 0 {
+				details += fmt.Sprintf(" | %d commits", len(session.Git.Commits))
+			}
+			if s.multiSource {
+				details += " | @" + truncate(session.SourceName(), 12)
+			}
+			if session.IsLive() {
+				details += fmt.Sprintf(" | CPU %.0f%% | %.0fMB", session.Process.CPUPercent, session.Process.MemoryMB)
+			}
 
+			base1, base2 := lipgloss.NewStyle(), MutedStyle
 			if isSelected {
-				line1 = HighlightStyle.Render(line1)
-				line2 = HighlightStyle.Render(line2)
-			} else {
-				line2 = MutedStyle.Render(line2)
+				base1, base2 = HighlightStyle, HighlightStyle
 			}
+			line1 := base1.Render(indicator+relativeTime+": ") +
+				highlightPositions(summary, s.matchPositions(session.SessionID, 0), base1, isSelected) +
+				base1.Render(branch)
+			line2 := base2.Render("    ") +
+				highlightPositions(truncate(session.ProjectName, 18), s.matchPositions(session.SessionID, 1), base2, isSelected) +
+				base2.Render(details)
 
 			lines = append(lines, line1)
 			lines = append(lines, line2)
@@ -407,7 +481,9 @@ func (s SessionsModel) GetKeybindings() []Keybinding {
 		{"s/S", "sort"},
 		{"/", "filter"},
 		{"y", "copy"},
-		{"enter", "details"},
+		{"enter", "transcript"},
+		{"d", "details"},
+		{"c", "resume"},
 	}
 }
 
@@ -72,7 +72,7 @@ func (s SparklineModel) View() string {
 		}
 
 		// Create block sparkline (better visual)
-		sparkline := s.blockSparkline(values)
+		sparkline := blockSparkline(values)
 		lines = append(lines, sparkline)
 		lines = append(lines, "")
 		lines = append(lines, s.statLine("Total:", fmt.Sprintf("%s messages", formatNumber(total))))
@@ -102,7 +102,7 @@ func (s SparklineModel) statLine(label, value string) string {
 
 // blockSparkline renders values using block characters with gradient colors.
 // Uses 8 block heights: ▁▂▃▄▅▆▇█
-func (s SparklineModel) blockSparkline(values []int) string {
+func blockSparkline(values []int) string {
 	if len(values) == 0 {
 		return ""
 	}
@@ -2,11 +2,79 @@ package ui
 
 import (
 	"fmt"
+	"math"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/charmbracelet/lipgloss"
 	"github.com/moshe-exe/lazyvibe/internal/data"
 )
 
+// StatsView represents which view the stats panel shows.
+type StatsView int
+
+const (
+	StatsViewOverview StatsView = iota
+	StatsViewModels
+)
+
+// Name returns the display name for the view.
+func (v StatsView) Name() string {
+	if v == StatsViewModels {
+		return "Models"
+	}
+	return "Overview"
+}
+
+// trendPeriods is how many periods, the current one last, the metric
+// sparklines cover.
+const trendPeriods = 8
+
+// statsTotals holds the overview metrics of a period.
+type statsTotals struct {
+	Projects int
+	Sessions int
+	Messages int
+	Tools    int
+	Tokens   int
+	Cost     float64
+}
+
+// statsTotalsIn sums the sessions and daily activity in a period.
+func statsTotalsIn(d *data.DashboardData, p data.Period) statsTotals {
+	var t statsTotals
+	projects := make(map[string]bool)
+	for _, sess := range d.SessionsIn(p) {
+		t.Sessions++
+		t.Messages += sess.MessageCount
+		projects[sess.ProjectPath] = true
+	}
+	t.Projects = len(projects)
+	for _, a := range d.DailyActivityIn(p) {
+		t.Tools += a.ToolCallCount
+		t.Tokens += a.TokenCount
+		t.Cost += a.Cost
+	}
+	return t
+}
+
+// statsMetric is a line of the overview.
+type statsMetric struct {
+	label  string
+	value  func(statsTotals) int // Comparable amount; cost in cents
+	format func(statsTotals) string
+}
+
+var statsMetrics = []statsMetric{
+	{"Projects", func(t statsTotals) int { return t.Projects }, func(t statsTotals) string { return fmt.Sprintf("%d", t.Projects) }},
+	{"Sessions", func(t statsTotals) int { return t.Sessions }, func(t statsTotals) string { return fmt.Sprintf("%d", t.Sessions) }},
+	{"Messages", func(t statsTotals) int { return t.Messages }, func(t statsTotals) string { return formatNumber(t.Messages) }},
+	{"Tools", func(t statsTotals) int { return t.Tools }, func(t statsTotals) string { return formatNumber(t.Tools) }},
+	{"Tokens", func(t statsTotals) int { return t.Tokens }, func(t statsTotals) string { return formatTokens(t.Tokens) }},
+	{"Cost", func(t statsTotals) int { return int(math.Round(t.Cost * 100)) }, func(t statsTotals) string { return formatCost(t.Cost) }},
+}
+
 // StatsModel represents the stats panel showing metrics.
 type StatsModel struct {
 	data      *data.DashboardData
@@ -14,6 +82,12 @@ type StatsModel struct {
 	width     int
 	height    int
 	timeRange data.TimeRange
+	view      StatsView
+
+	totals   statsTotals   // Metrics of the time range
+	trend    []statsTotals // Metrics of the periods up to the range's, oldest first
+	previous data.Period   // Period compared with, if trend has two or more
+	partial  bool          // Whether the range includes today, counted so far
 }
 
 // NewStatsModel creates a new stats model.
@@ -25,6 +99,23 @@ func NewStatsModel() StatsModel {
 func (s *StatsModel) Update(d *data.DashboardData, timeRange data.TimeRange) {
 	s.data = d
 	s.timeRange = timeRange
+	now := time.Now()
+	period := timeRange.PeriodAt(now)
+	s.totals = statsTotalsIn(d, period)
+	s.partial = period.Contains(now)
+
+	// Earlier periods of the same length to compare with. Daily activity
+	// has no time of day, so their last day counts in full
+	s.trend = nil
+	for n := trendPeriods - 1; n > 0; n-- {
+		if p, ok := timeRange.PeriodBack(now, n); ok {
+			s.trend = append(s.trend, statsTotalsIn(d, p))
+		}
+	}
+	if len(s.trend) > 0 {
+		s.trend = append(s.trend, s.totals)
+		s.previous, _ = timeRange.PeriodBack(now, 1)
+	}
 }
 
 // SetFocused sets the focus state.
@@ -38,6 +129,11 @@ func (s *StatsModel) SetSize(width, height int) {
 	s.height = height
 }
 
+// CycleView toggles between the overview and the model breakdown.
+func (s *StatsModel) CycleView() {
+	s.view = (s.view + 1) % 2
+}
+
 // View renders the stats panel.
 func (s StatsModel) View() string {
 	var lines []string
@@ -45,16 +141,24 @@ func (s StatsModel) View() string {
 	// Title with panel number and time range
 	title := PanelTitleStyle.Render("Stats")
 	numKey := MutedStyle.Render(" 1")
+	view := MutedStyle.Render(" [" + s.view.Name() + "]")
 	timeRange := MutedStyle.Render(" [" + s.timeRange.String() + "]")
-	lines = append(lines, title+numKey+timeRange)
+	lines = append(lines, title+numKey+view+timeRange)
 	lines = append(lines, "")
 
 	if s.data == nil {
 		lines = append(lines, MutedStyle.Render("Loading..."))
+	} else if s.view == StatsViewModels {
+		lines = append(lines, s.renderModels()...)
 	} else {
 		lines = append(lines, s.renderMetrics()...)
 	}
 
+	// Clip to the panel so extra metrics don't push the layout
+	if s.height > 2 && len(lines) > s.height-2 {
+		lines = lines[:s.height-2]
+	}
+
 	content := strings.Join(lines, "\n")
 
 	// Apply border
@@ -69,33 +173,128 @@ func (s StatsModel) View() string {
 	return style.Render(content)
 }
 
-// renderMetrics renders the metrics as a single column.
+// renderMetrics renders the metrics as a single column, each with its
+// change from the previous period and its trend when the range has one.
 func (s StatsModel) renderMetrics() []string {
-	// Get filtered data based on time range
-	filteredProjects := s.data.FilterProjects(s.timeRange)
-	filteredSessions := s.data.FilterSessions(s.timeRange)
-	filteredActivity := s.data.FilterDailyActivity(s.timeRange)
+	previous := statsTotals{}
+	if len(s.trend) > 1 {
+		previous = s.trend[len(s.trend)-2]
+	}
+
+	// Values and changes are padded to line up; the sparkline is dropped
+	// and then the change when the panel is narrow
+	contentWidth := s.width - 4
+	const valueW, changeW = 9, 8
+	showChange := len(s.trend) > 1 && contentWidth >= 12+valueW+changeW
+	showTrend := showChange && contentWidth >= 12+valueW+changeW+len(s.trend)
+
+	lines := make([]string, 0, len(statsMetrics)+1)
+	for _, m := range statsMetrics {
+		line := s.metricLine(m.label, fmt.Sprintf("%-*s", valueW, m.format(s.totals)))
+		if showChange {
+			line += changeText(m.value(s.totals), m.value(previous), changeW)
+		}
+		if showTrend {
+			values := make([]int, len(s.trend))
+			for i, t := range s.trend {
+				values[i] = m.value(t)
+			}
+			line += blockSparkline(values)
+		}
+		lines = append(lines, line)
+	}
+	if len(s.trend) > 1 {
+		vs := "vs " + s.previous.String()
+		if s.partial {
+			vs += " in full; today so far"
+		}
+		lines = append(lines, "  "+MutedStyle.Render(truncate(vs, max(contentWidth-2, 1))))
+	}
+	return lines
+}
+
+// changeText renders the change from previous to current, e.g. "▲ 25%",
+// padded to width.
+func changeText(current, previous, width int) string {
+	var text string
+	style := MutedStyle
+	switch {
+	case current == previous:
+		text = "="
+	case previous == 0:
+		text, style = "new", lipgloss.NewStyle().Foreground(Success)
+	default:
+		pct := float64(current-previous) / float64(previous) * 100
+		percent := fmt.Sprintf("%.0f%%", math.Abs(pct))
+		if math.Abs(pct) >= 1000 {
+			percent = ">999%"
+		}
+		if pct > 0 {
+			text, style = "▲ "+percent, lipgloss.NewStyle().Foreground(Success)
+		} else {
+			text, style = "▼ "+percent, lipgloss.NewStyle().Foreground(Error)
+		}
+	}
+	return style.Render(fmt.Sprintf("%-*s", width, text))
+}
 
-	// Calculate totals from filtered data
-	totalMessages := 0
-	for _, sess := range filteredSessions {
-		totalMessages += sess.MessageCount
+// renderModels renders the per-model breakdown as a ranked table.
+func (s StatsModel) renderModels() []string {
+	models := s.data.ModelBreakdown(s.timeRange)
+	if len(models) == 0 {
+		return []string{"  " + MutedStyle.Render("No model usage found")}
 	}
 
-	totalTools := 0
 	totalTokens := 0
-	for _, a := range filteredActivity {
-		totalTools += a.ToolCallCount
-		totalTokens += a.TokenCount
+	for _, m := range models {
+		totalTokens += m.Tokens.Total()
 	}
 
-	return []string{
-		s.metricLine("Projects", fmt.Sprintf("%d", len(filteredProjects))),
-		s.metricLine("Sessions", fmt.Sprintf("%d", len(filteredSessions))),
-		s.metricLine("Messages", formatNumber(totalMessages)),
-		s.metricLine("Tools", formatNumber(totalTools)),
-		s.metricLine("Tokens", formatTokens(totalTokens)),
+	// Columns: Model (10), Share (flex), Tokens (6), Msgs (5), Sess (4)
+	nameW := 10
+	tokensW := 6
+	msgsW := 5
+	sessW := 4
+	contentWidth := s.width - 4
+	barW := contentWidth - 2 - nameW - tokensW - msgsW - sessW - 4
+	if barW < 4 {
+		barW = 4
 	}
+
+	header := fmt.Sprintf("  %-*s %-*s %*s %*s %*s",
+		nameW, "Model",
+		barW, "Share",
+		tokensW, "Tokens",
+		msgsW, "Msgs",
+		sessW, "Sess")
+	lines := []string{MutedStyle.Render(header)}
+
+	for _, m := range models {
+		percent := 0.0
+		if totalTokens > 0 {
+			percent = float64(m.Tokens.Total()) / float64(totalTokens) * 100
+		}
+		name := StatLabelStyle.Render(fmt.Sprintf("%-*s", nameW, truncate(shortModelName(m.Model), nameW)))
+		values := StatValueStyle.Render(fmt.Sprintf("%*s %*s %*d",
+			tokensW, formatTokens(m.Tokens.Total()),
+			msgsW, formatNumber(m.Messages),
+			sessW, m.Sessions))
+		lines = append(lines, "  "+name+" "+RenderBar(percent, barW)+" "+values)
+	}
+
+	return lines
+}
+
+// shortModelName strips the vendor prefix and date suffix from a model ID,
+// e.g. "claude-opus-4-1-20250805" becomes "opus-4-1".
+func shortModelName(model string) string {
+	name := strings.TrimPrefix(model, "claude-")
+	if i := strings.LastIndex(name, "-"); i >= 0 && len(name)-i-1 == 8 {
+		if _, err := strconv.Atoi(name[i+1:]); err == nil {
+			name = name[:i]
+		}
+	}
+	return name
 }
 
 // metricLine renders a single metric line with 2-char margin.
@@ -108,15 +307,25 @@ func (s StatsModel) metricLine(label, value string) string {
 // formatTokens formats token count with K/M suffix.
 func formatTokens(n int) string {
 	if n >= 1000000 {
-		return fmt.Sprintf("~%.1fM", float64(n)/1000000)
+		return fmt.Sprintf("%.1fM", float64(n)/1000000)
 	}
 	if n >= 1000 {
-		return fmt.Sprintf("~%.0fK", float64(n)/1000)
+		return fmt.Sprintf("%.0fK", float64(n)/1000)
 	}
-	return fmt.Sprintf("~%d", n)
+	return fmt.Sprintf("%d", n)
+}
+
+// formatCost formats a USD amount.
+func formatCost(c float64) string {
+	if c >= 1000 {
+		return "$" + formatNumber(int(c+0.5))
+	}
+	return fmt.Sprintf("$%.2f", c)
 }
 
 // GetKeybindings returns context-specific keybindings for this panel.
 func (s StatsModel) GetKeybindings() []Keybinding {
-	return []Keybinding{}
+	return []Keybinding{
+		{"v", "view"},
+	}
 }
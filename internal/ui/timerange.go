@@ -0,0 +1,213 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/moshe-exe/lazyvibe/internal/data"
+)
+
+// rangeItem is a time range the range picker offers.
+type rangeItem struct {
+	key    string // Shortcut within the picker
+	label  string
+	tr     data.TimeRange
+	custom bool // Asks for dates instead
+}
+
+// rangeItems lists the picker's ranges, ending with custom dates.
+var rangeItems = []rangeItem{
+	{key: "d", label: "Today", tr: data.TimeToday},
+	{key: "w", label: "This week", tr: data.TimeWeek},
+	{key: "m", label: "This month", tr: data.TimeMonth},
+	{key: "y", label: "Year to date", tr: data.TimeYear},
+	{key: "7", label: "Last 7 days", tr: data.LastDays(7)},
+	{key: "3", label: "Last 30 days", tr: data.LastDays(30)},
+	{key: "9", label: "Last 90 days", tr: data.LastDays(90)},
+	{key: "a", label: "All time", tr: data.TimeAll},
+	{key: "c", label: "Custom…", custom: true},
+}
+
+// RangePicker represents the modal for choosing the time range.
+type RangePicker struct {
+	visible bool
+	cursor  int
+	current data.TimeRange
+	editing bool   // Typing custom dates
+	input   string // Custom dates as typed
+	err     string // Why the typed dates were rejected
+	width   int
+	height  int
+}
+
+// NewRangePicker creates a new range picker.
+func NewRangePicker() RangePicker {
+	return RangePicker{}
+}
+
+// SetSize sets the available dimensions.
+func (r *RangePicker) SetSize(width, height int) {
+	r.width = width
+	r.height = height
+}
+
+// Show displays the picker with the current range selected.
+func (r *RangePicker) Show(current data.TimeRange) {
+	r.visible = true
+	r.current = current
+	r.editing = false
+	r.err = ""
+	r.cursor = len(rangeItems) - 1
+	for i, item := range rangeItems {
+		if !item.custom && item.tr == current {
+			r.cursor = i
+		}
+	}
+}
+
+// Hide hides the picker.
+func (r *RangePicker) Hide() {
+	r.visible = false
+}
+
+// IsVisible returns whether the picker is visible.
+func (r *RangePicker) IsVisible() bool {
+	return r.visible
+}
+
+// IsEditing returns whether custom dates are being typed.
+func (r *RangePicker) IsEditing() bool {
+	return r.editing
+}
+
+// CursorUp moves the selection up.
+func (r *RangePicker) CursorUp() {
+	if r.cursor > 0 {
+		r.cursor--
+	}
+}
+
+// CursorDown moves the selection down.
+func (r *RangePicker) CursorDown() {
+	if r.cursor < len(rangeItems)-1 {
+		r.cursor++
+	}
+}
+
+// Selected returns the selected item.
+func (r RangePicker) Selected() rangeItem {
+	return rangeItems[r.cursor]
+}
+
+// ItemForKey returns the item with the given shortcut.
+func (r RangePicker) ItemForKey(key string) (rangeItem, bool) {
+	for _, item := range rangeItems {
+		if item.key == key {
+			return item, true
+		}
+	}
+	return rangeItem{}, false
+}
+
+// StartEditing asks for custom dates, starting from the dates the current
+// range covers.
+func (r *RangePicker) StartEditing() {
+	r.editing = true
+	r.err = ""
+	r.input = ""
+	if !r.current.IsAll() {
+		r.input = r.current.Period().String()
+	}
+	r.cursor = len(rangeItems) - 1
+}
+
+// StopEditing returns to the list of ranges.
+func (r *RangePicker) StopEditing() {
+	r.editing = false
+	r.err = ""
+}
+
+// HandleInput appends a character to the custom dates.
+func (r *RangePicker) HandleInput(char string) {
+	r.input += char
+	r.err = ""
+}
+
+// HandleBackspace removes the last character of the custom dates.
+func (r *RangePicker) HandleBackspace() {
+	if len(r.input) > 0 {
+		r.input = r.input[:len(r.input)-1]
+	}
+	r.err = ""
+}
+
+// ParseInput returns the range the custom dates describe. If they are
+// invalid, the picker shows why and it returns false.
+func (r *RangePicker) ParseInput() (data.TimeRange, bool) {
+	tr, err := data.ParseTimeRange(r.input)
+	if err != nil {
+		r.err = err.Error()
+		return data.TimeRange{}, false
+	}
+	return tr, true
+}
+
+// View renders the range picker.
+func (r RangePicker) View() string {
+	if !r.visible {
+		return ""
+	}
+
+	modalWidth := min(max(r.width*50/100, 44), 64)
+	lines := []string{
+		PanelTitleStyle.Render("Time Range") + MutedStyle.Render(" "+r.current.String()),
+		MutedStyle.Render(strings.Repeat("-", modalWidth-4)),
+	}
+	for i, item := range rangeItems {
+		detail := ""
+		if !item.custom {
+			detail = item.tr.Period().String()
+			if item.tr.IsAll() {
+				detail = ""
+			}
+		}
+		row := fmt.Sprintf("%s %-14s", item.key, item.label)
+		if i == r.cursor && !r.editing {
+			lines = append(lines, HighlightStyle.Render("▶ "+row)+" "+MutedStyle.Render(detail))
+		} else {
+			lines = append(lines, "  "+HelpKeyStyle.Render(item.key)+fmt.Sprintf(" %-14s", item.label)+" "+MutedStyle.Render(detail))
+		}
+	}
+
+	lines = append(lines, "")
+	if r.editing {
+		lines = append(lines,
+			lipgloss.NewStyle().Foreground(Primary).Render("> "+r.input+"█"),
+			MutedStyle.Render("YYYY-MM-DD or FROM..TO, either side optional, or Nd"))
+		if r.err != "" {
+			lines = append(lines, ErrorStyle.Width(modalWidth-8).Render(r.err))
+		}
+		lines = append(lines, "", MutedStyle.Render("enter apply  esc back"))
+	} else {
+		lines = append(lines, MutedStyle.Render(fmt.Sprintf("key or enter choose  esc close  weeks start %s", data.WeekStart())))
+	}
+
+	modal := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(Primary).
+		Padding(1, 2).
+		Width(modalWidth).
+		Render(strings.Join(lines, "\n"))
+
+	// Center the modal
+	paddingLeft := max((r.width-modalWidth)/2, 0)
+	paddingTop := max((r.height-lipgloss.Height(modal))/2, 0)
+
+	leftPadding := strings.Repeat(" ", paddingLeft)
+	modalLines := strings.Split(modal, "\n")
+	for i, line := range modalLines {
+		modalLines[i] = leftPadding + line
+	}
+	return strings.Repeat("\n", paddingTop) + strings.Join(modalLines, "\n")
+}
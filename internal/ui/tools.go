@@ -0,0 +1,424 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/moshe-exe/lazyvibe/internal/data"
+)
+
+// ToolSortField represents the field to sort tools by.
+type ToolSortField int
+
+const (
+	ToolSortByCalls ToolSortField = iota
+	ToolSortByErrors
+	ToolSortByErrorRate
+	ToolSortByLatency
+	ToolSortByName
+)
+
+// ToolsModel represents the tool analytics table component.
+type ToolsModel struct {
+	tools       []data.ToolUsage
+	allTools    []data.ToolUsage // Unfiltered list
+	cursor      int
+	offset      int
+	focused     bool
+	width       int
+	height      int
+	sortField   ToolSortField
+	sortDesc    bool
+	filterQuery string
+	filterMode  bool
+	timeRange   data.TimeRange
+}
+
+// NewToolsModel creates a new tools model.
+func NewToolsModel() ToolsModel {
+	return ToolsModel{
+		sortField: ToolSortByCalls, // Default sort by call count
+		sortDesc:  true,            // Most used first
+	}
+}
+
+// Update updates the tools data.
+func (t *ToolsModel) Update(tools []data.ToolUsage, timeRange data.TimeRange) {
+	t.allTools = make([]data.ToolUsage, len(tools))
+	copy(t.allTools, tools)
+	t.timeRange = timeRange
+	t.applyFilter()
+	t.sortTools()
+
+	// Reset cursor if out of bounds
+	if t.cursor >= len(t.tools) {
+		t.cursor = max(0, len(t.tools)-1)
+	}
+}
+
+// applyFilter filters tools based on the current filter query.
+func (t *ToolsModel) applyFilter() {
+	if t.filterQuery == "" {
+		t.tools = make([]data.ToolUsage, len(t.allTools))
+		copy(t.tools, t.allTools)
+		return
+	}
+
+	query := strings.ToLower(t.filterQuery)
+	var filtered []data.ToolUsage
+	for _, tool := range t.allTools {
+		if strings.Contains(strings.ToLower(tool.Name), query) {
+			filtered = append(filtered, tool)
+		}
+	}
+	t.tools = filtered
+}
+
+// SetFilterMode enables or disables filter mode.
+func (t *ToolsModel) SetFilterMode(enabled bool) {
+	t.filterMode = enabled
+	if !enabled {
+		t.filterQuery = ""
+		t.applyFilter()
+		t.sortTools()
+	}
+}
+
+// IsFilterMode returns whether filter mode is active.
+func (t *ToolsModel) IsFilterMode() bool {
+	return t.filterMode
+}
+
+// HandleFilterInput handles a character input in filter mode.
+func (t *ToolsModel) HandleFilterInput(char string) {
+	t.filterQuery += char
+	t.applyFilter()
+	t.sortTools()
+	t.cursor = 0
+	t.offset = 0
+}
+
+// HandleFilterBackspace removes the last character from the filter query.
+func (t *ToolsModel) HandleFilterBackspace() {
+	if len(t.filterQuery) > 0 {
+		t.filterQuery = t.filterQuery[:len(t.filterQuery)-1]
+		t.applyFilter()
+		t.sortTools()
+	}
+}
+
+// GetFilteredCount returns filtered/total count string.
+func (t *ToolsModel) GetFilteredCount() string {
+	if t.filterQuery == "" {
+		return ""
+	}
+	return fmt.Sprintf("%d/%d", len(t.tools), len(t.allTools))
+}
+
+// sortTools sorts the tools based on current sort field and direction.
+func (t *ToolsModel) sortTools() {
+	sort.SliceStable(t.tools, func(i, j int) bool {
+		var less bool
+		switch t.sortField {
+		case ToolSortByCalls:
+			less = t.tools[i].Calls < t.tools[j].Calls
+		case ToolSortByErrors:
+			less = t.tools[i].Errors < t.tools[j].Errors
+		case ToolSortByErrorRate:
+			less = t.tools[i].ErrorRate() < t.tools[j].ErrorRate()
+		case ToolSortByLatency:
+			less = t.tools[i].AvgLatency() < t.tools[j].AvgLatency()
+		case ToolSortByName:
+			less = t.tools[i].Name < t.tools[j].Name
+		}
+		if t.sortDesc {
+			return !less
+		}
+		return less
+	})
+}
+
+// CycleSort cycles through sort fields.
+func (t *ToolsModel) CycleSort() {
+	t.sortField = (t.sortField + 1) % 5
+	t.sortTools()
+}
+
+// ToggleSortDirection toggles between ascending and descending.
+func (t *ToolsModel) ToggleSortDirection() {
+	t.sortDesc = !t.sortDesc
+	t.sortTools()
+}
+
+// sortFieldName returns the display name for the sort field.
+func (t ToolsModel) sortFieldName() string {
+	switch t.sortField {
+	case ToolSortByErrors:
+		return "Errors"
+	case ToolSortByErrorRate:
+		return "Error %"
+	case ToolSortByLatency:
+		return "Latency"
+	case ToolSortByName:
+		return "Name"
+	}
+	return "Calls"
+}
+
+// SetFocused sets the focus state.
+func (t *ToolsModel) SetFocused(focused bool) {
+	t.focused = focused
+}
+
+// SetSize sets the panel dimensions.
+func (t *ToolsModel) SetSize(width, height int) {
+	t.width = width
+	t.height = height
+}
+
+// CursorUp moves the cursor up.
+func (t *ToolsModel) CursorUp() {
+	if t.cursor > 0 {
+		t.cursor--
+		t.ensureVisible()
+	}
+}
+
+// CursorDown moves the cursor down.
+func (t *ToolsModel) CursorDown() {
+	if t.cursor < len(t.tools)-1 {
+		t.cursor++
+		t.ensureVisible()
+	}
+}
+
+// CursorUpN moves the cursor up by n items.
+func (t *ToolsModel) CursorUpN(n int) {
+	t.cursor -= n
+	if t.cursor < 0 {
+		t.cursor = 0
+	}
+	t.ensureVisible()
+}
+
+// CursorDownN moves the cursor down by n items.
+func (t *ToolsModel) CursorDownN(n int) {
+	t.cursor += n
+	if t.cursor >= len(t.tools) {
+		t.cursor = len(t.tools) - 1
+	}
+	if t.cursor < 0 {
+		t.cursor = 0
+	}
+	t.ensureVisible()
+}
+
+// CursorTop moves the cursor to the first row.
+func (t *ToolsModel) CursorTop() {
+	t.cursor = 0
+	t.ensureVisible()
+}
+
+// CursorBottom moves the cursor to the last row.
+func (t *ToolsModel) CursorBottom() {
+	t.cursor = max(0, len(t.tools)-1)
+	t.ensureVisible()
+}
+
+func (t *ToolsModel) ensureVisible() {
+	visibleRows := t.visibleRows()
+	if visibleRows <= 0 {
+		return
+	}
+
+	if t.cursor < t.offset {
+		t.offset = t.cursor
+	} else if t.cursor >= t.offset+visibleRows {
+		t.offset = t.cursor - visibleRows + 1
+	}
+}
+
+func (t *ToolsModel) visibleRows() int {
+	// Account for border, title, header, separator
+	if t.height > 0 {
+		return t.height - 6
+	}
+	return 10
+}
+
+// View renders the tools table.
+func (t ToolsModel) View() string {
+	var lines []string
+
+	// Title with panel number, sort indicator, and time range
+	sortIndicator := "↓"
+	if !t.sortDesc {
+		sortIndicator = "↑"
+	}
+
+	title := PanelTitleStyle.Render("Tools")
+	numKey := MutedStyle.Render(" 5")
+	sortInfo := MutedStyle.Render(fmt.Sprintf(" [%s %s]", t.sortFieldName(), sortIndicator))
+	timeRange := MutedStyle.Render(" [" + t.timeRange.String() + "]")
+
+	titleLine := title + numKey + sortInfo
+	// Show filter count if filtering
+	if t.filterQuery != "" {
+		titleLine += " " + MutedStyle.Render(t.GetFilteredCount())
+	}
+	titleLine += timeRange
+
+	// Add nav hints on the right when focused
+	if t.focused && !t.filterMode {
+		navHints := MutedStyle.Render("j/k u/i g/G")
+		leftWidth := lipgloss.Width(titleLine)
+		rightWidth := lipgloss.Width(navHints)
+		contentWidth := t.width - 4 // Account for border and padding
+		padding := contentWidth - leftWidth - rightWidth
+		if padding < 2 {
+			padding = 2
+		}
+		titleLine += strings.Repeat(" ", padding) + navHints
+	}
+
+	lines = append(lines, titleLine)
+
+	// Show filter input if in filter mode
+	if t.filterMode {
+		filterLine := "/" + t.filterQuery + "█"
+		lines = append(lines, lipgloss.NewStyle().Foreground(Primary).Render(filterLine))
+	} else {
+		lines = append(lines, "")
+	}
+
+	// Calculate column widths
+	// Account for scrollbar (2 chars: "▓ " or "░ ")
+	scrollbarW := 2
+	contentWidth := t.width - 4 - scrollbarW // Account for border, padding, and scrollbar
+	if contentWidth < 40 {
+		contentWidth = 40
+	}
+
+	// Column widths: Tool (flex), Calls (7), Errors (7), Err% (6), Avg (8), Sessions (8)
+	// Account for selection indicator (2 chars: "▶ " or "  ")
+	indicatorW := 2
+	callsW := 7
+	errorsW := 7
+	rateW := 6
+	latencyW := 8
+	sessionsW := 8
+	toolW := contentWidth - indicatorW - callsW - errorsW - rateW - latencyW - sessionsW - 5 // 5 for spacing
+	if toolW < 10 {
+		toolW = 10
+	}
+
+	// Header (with indicator spacing)
+	header := fmt.Sprintf("%*s%-*s %*s %*s %*s %*s %*s",
+		indicatorW, "",
+		toolW, "Tool",
+		callsW, "Calls",
+		errorsW, "Errors",
+		rateW, "Err%",
+		latencyW, "Avg",
+		sessionsW, "Sessions")
+	lines = append(lines, MutedStyle.Render(header))
+	lines = append(lines, MutedStyle.Render(strings.Repeat("-", contentWidth)))
+
+	visibleRows := t.visibleRows()
+
+	if len(t.tools) == 0 {
+		lines = append(lines, MutedStyle.Render("No tool calls found"))
+	} else {
+		endIdx := min(t.offset+visibleRows, len(t.tools))
+
+		for i := t.offset; i < endIdx; i++ {
+			tool := t.tools[i]
+			isSelected := i == t.cursor && t.focused
+
+			// Selection indicator
+			indicator := "  "
+			if isSelected {
+				indicator = "▶ "
+			}
+
+			row := fmt.Sprintf("%s%-*s %*s %*s %*s %*s %*d",
+				indicator,
+				toolW, truncate(tool.Name, toolW),
+				callsW, formatNumber(tool.Calls),
+				errorsW, formatNumber(tool.Errors),
+				rateW, fmt.Sprintf("%.0f%%", tool.ErrorRate()*100),
+				latencyW, formatLatency(tool.AvgLatency()),
+				sessionsW, tool.Sessions)
+
+			if isSelected {
+				row = HighlightStyle.Render(row)
+			}
+			lines = append(lines, row)
+		}
+	}
+
+	// Build scrollbar
+	scrollbar := RenderScrollbar(len(t.tools), visibleRows, t.offset, visibleRows)
+	scrollbarLines := strings.Split(scrollbar, "\n")
+
+	// Join content lines with scrollbar
+	content := strings.Join(lines, "\n")
+
+	// If we have a scrollbar, join it to the right of the content
+	if len(scrollbarLines) > 0 && scrollbar != "" {
+		contentLines := strings.Split(content, "\n")
+		var combined []string
+		// First lines are title, blank, header, separator (4 lines)
+		headerLines := 4
+		for i, line := range contentLines {
+			scrollChar := " "
+			if i >= headerLines && i-headerLines < len(scrollbarLines) {
+				scrollChar = scrollbarLines[i-headerLines]
+			}
+			combined = append(combined, line+" "+scrollChar)
+		}
+		content = strings.Join(combined, "\n")
+	}
+
+	// Apply border
+	style := PanelStyle(t.focused)
+	if t.width > 0 {
+		style = style.Width(t.width - 2)
+	}
+	if t.height > 0 {
+		style = style.Height(t.height - 2)
+	}
+
+	return style.Render(content)
+}
+
+// formatLatency formats a duration compactly, e.g. "850ms" or "2.4s".
+func formatLatency(d time.Duration) string {
+	switch {
+	case d <= 0:
+		return "-"
+	case d < time.Second:
+		return fmt.Sprintf("%dms", d.Milliseconds())
+	case d < time.Minute:
+		return fmt.Sprintf("%.1fs", d.Seconds())
+	default:
+		return fmt.Sprintf("%.1fm", d.Minutes())
+	}
+}
+
+// GetKeybindings returns context-specific keybindings for this panel.
+func (t ToolsModel) GetKeybindings() []Keybinding {
+	if t.filterMode {
+		return []Keybinding{
+			{"esc", "clear"},
+			{"enter", "apply"},
+		}
+	}
+	return []Keybinding{
+		{"s/S", "sort"},
+		{"/", "filter"},
+	}
+}
@@ -0,0 +1,539 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/moshe-exe/lazyvibe/internal/data"
+	"github.com/moshe-exe/lazyvibe/internal/util"
+)
+
+// TranscriptModal represents a scrollable view of a session transcript.
+type TranscriptModal struct {
+	visible  bool
+	session  *data.SessionEntry
+	entries  []data.TranscriptEntry
+	err      error
+	expanded map[int]bool // Foldable entries that are unfolded
+	selected int          // Selected foldable entry, -1 for none
+	offset   int          // First visible line
+	width    int
+	height   int
+
+	searchMode  bool
+	searchQuery string
+	matches     []int // Entry indices matching searchQuery
+	matchIdx    int
+
+	lines     []string // Rendered lines
+	entryLine []int    // First rendered line of each entry
+}
+
+// NewTranscriptModal creates a new transcript modal.
+func NewTranscriptModal() TranscriptModal {
+	return TranscriptModal{selected: -1}
+}
+
+// SetSize sets the modal dimensions.
+func (t *TranscriptModal) SetSize(width, height int) {
+	t.width = width
+	t.height = height
+	if t.visible {
+		t.render()
+	}
+}
+
+// Show loads and displays the transcript of the given session.
+func (t *TranscriptModal) Show(session *data.SessionEntry) {
+	t.session = session
+	t.entries, t.err = data.LoadTranscript(session.TranscriptPath)
+	t.expanded = make(map[int]bool)
+	t.selected = -1
+	t.offset = 0
+	t.searchMode = false
+	t.searchQuery = ""
+	t.matches = nil
+	t.matchIdx = 0
+	t.visible = true
+	t.render()
+}
+
+// Hide hides the modal.
+func (t *TranscriptModal) Hide() {
+	t.visible = false
+	t.session = nil
+	t.entries = nil
+	t.lines = nil
+}
+
+// IsVisible returns whether the modal is visible.
+func (t *TranscriptModal) IsVisible() bool {
+	return t.visible
+}
+
+// IsSearchMode returns whether the search prompt is active.
+func (t *TranscriptModal) IsSearchMode() bool {
+	return t.searchMode
+}
+
+// visibleLines returns the number of transcript lines that fit.
+func (t TranscriptModal) visibleLines() int {
+	// Border (2), title and separator (2), status line (1)
+	rows := t.height - 5
+	if rows < 1 {
+		rows = 1
+	}
+	return rows
+}
+
+// contentWidth returns the width available for transcript text.
+func (t TranscriptModal) contentWidth() int {
+	// Border (2), padding (2), gutter (2)
+	w := t.width - 6
+	if w < 20 {
+		w = 20
+	}
+	return w
+}
+
+// ScrollUp scrolls up by n lines.
+func (t *TranscriptModal) ScrollUp(n int) {
+	t.offset -= n
+	t.clampOffset()
+}
+
+// ScrollDown scrolls down by n lines.
+func (t *TranscriptModal) ScrollDown(n int) {
+	t.offset += n
+	t.clampOffset()
+}
+
+// PageUp scrolls up by a page.
+func (t *TranscriptModal) PageUp() {
+	t.ScrollUp(t.visibleLines() - 1)
+}
+
+// PageDown scrolls down by a page.
+func (t *TranscriptModal) PageDown() {
+	t.ScrollDown(t.visibleLines() - 1)
+}
+
+// GotoTop scrolls to the start of the transcript.
+func (t *TranscriptModal) GotoTop() {
+	t.offset = 0
+}
+
+// GotoBottom scrolls to the end of the transcript.
+func (t *TranscriptModal) GotoBottom() {
+	t.offset = len(t.lines)
+	t.clampOffset()
+}
+
+func (t *TranscriptModal) clampOffset() {
+	maxOffset := len(t.lines) - t.visibleLines()
+	if t.offset > maxOffset {
+		t.offset = maxOffset
+	}
+	if t.offset < 0 {
+		t.offset = 0
+	}
+}
+
+// isFoldable returns whether an entry has a collapsible body.
+func (t TranscriptModal) isFoldable(idx int) bool {
+	kind := t.entries[idx].Kind
+	return kind == data.EntryTool || kind == data.EntryThinking
+}
+
+// SelectNext selects the next tool call or thinking block.
+func (t *TranscriptModal) SelectNext() {
+	for i := t.selected + 1; i < len(t.entries); i++ {
+		if t.isFoldable(i) {
+			t.selectEntry(i)
+			return
+		}
+	}
+}
+
+// SelectPrev selects the previous tool call or thinking block.
+func (t *TranscriptModal) SelectPrev() {
+	start := t.selected - 1
+	if t.selected < 0 {
+		start = len(t.entries) - 1
+	}
+	for i := start; i >= 0; i-- {
+		if t.isFoldable(i) {
+			t.selectEntry(i)
+			return
+		}
+	}
+}
+
+func (t *TranscriptModal) selectEntry(idx int) {
+	t.selected = idx
+	t.render()
+	t.scrollToEntry(idx)
+}
+
+// scrollToEntry scrolls so the entry's first line is visible.
+func (t *TranscriptModal) scrollToEntry(idx int) {
+	if idx < 0 || idx >= len(t.entryLine) {
+		return
+	}
+	line := t.entryLine[idx]
+	if line < t.offset || line >= t.offset+t.visibleLines() {
+		t.offset = line - t.visibleLines()/3
+		t.clampOffset()
+	}
+}
+
+// ToggleSelected folds or unfolds the selected entry.
+func (t *TranscriptModal) ToggleSelected() {
+	if t.selected < 0 {
+		return
+	}
+	t.expanded[t.selected] = !t.expanded[t.selected]
+	t.render()
+	t.clampOffset()
+}
+
+// SetAllExpanded folds or unfolds every entry.
+func (t *TranscriptModal) SetAllExpanded(expanded bool) {
+	for i := range t.entries {
+		if t.isFoldable(i) {
+			t.expanded[i] = expanded
+		}
+	}
+	t.render()
+	t.clampOffset()
+}
+
+// SetSearchMode enables or disables the search prompt.
+// Disabling clears the search.
+func (t *TranscriptModal) SetSearchMode(enabled bool) {
+	t.searchMode = enabled
+	if !enabled {
+		t.searchQuery = ""
+		t.matches = nil
+		t.render()
+	}
+}
+
+// ApplySearch closes the search prompt and jumps to the first match.
+func (t *TranscriptModal) ApplySearch() {
+	t.searchMode = false
+	t.findMatches()
+	if len(t.matches) > 0 {
+		t.matchIdx = 0
+		t.gotoMatch()
+	}
+}
+
+// HandleSearchInput adds a character to the search query.
+func (t *TranscriptModal) HandleSearchInput(char string) {
+	t.searchQuery += char
+}
+
+// HandleSearchBackspace removes the last character from the search query.
+func (t *TranscriptModal) HandleSearchBackspace() {
+	if len(t.searchQuery) > 0 {
+		t.searchQuery = t.searchQuery[:len(t.searchQuery)-1]
+	}
+}
+
+// NextMatch jumps to the next search match.
+func (t *TranscriptModal) NextMatch() {
+	if len(t.matches) == 0 {
+		return
+	}
+	t.matchIdx = (t.matchIdx + 1) % len(t.matches)
+	t.gotoMatch()
+}
+
+// PrevMatch jumps to the previous search match.
+func (t *TranscriptModal) PrevMatch() {
+	if len(t.matches) == 0 {
+		return
+	}
+	t.matchIdx = (t.matchIdx - 1 + len(t.matches)) % len(t.matches)
+	t.gotoMatch()
+}
+
+// gotoMatch unfolds the current match and scrolls to it.
+func (t *TranscriptModal) gotoMatch() {
+	idx := t.matches[t.matchIdx]
+	if t.isFoldable(idx) {
+		t.expanded[idx] = true
+		t.selected = idx
+	}
+	t.render()
+	t.scrollToEntry(idx)
+}
+
+// findMatches collects entries containing the search query.
+func (t *TranscriptModal) findMatches() {
+	t.matches = nil
+	if t.searchQuery == "" {
+		t.render()
+		return
+	}
+	query := strings.ToLower(t.searchQuery)
+	for i, entry := range t.entries {
+		text := entry.Text + "\n" + entry.ToolName + "\n" + entry.ToolInput + "\n" + entry.Result
+		if strings.Contains(strings.ToLower(text), query) {
+			t.matches = append(t.matches, i)
+		}
+	}
+	t.render()
+}
+
+// render rebuilds the rendered lines from the entries.
+func (t *TranscriptModal) render() {
+	t.lines = nil
+	t.entryLine = make([]int, len(t.entries))
+	width := t.contentWidth()
+
+	for i, entry := range t.entries {
+		t.entryLine[i] = len(t.lines)
+
+		gutter := "  "
+		if i == t.selected {
+			gutter = lipgloss.NewStyle().Foreground(Primary).Render("▌ ")
+		}
+
+		for _, line := range t.renderEntry(i, entry, width) {
+			t.lines = append(t.lines, gutter+line)
+		}
+		t.lines = append(t.lines, "")
+	}
+}
+
+// renderEntry renders a single entry's header and body.
+func (t TranscriptModal) renderEntry(idx int, entry data.TranscriptEntry, width int) []string {
+	timestamp := MutedStyle.Render(fmt.Sprintf("%-8s ", util.FormatTime(entry.Timestamp.Local(), "15:04:05")))
+	expanded := t.expanded[idx]
+	bodyStyle := lipgloss.NewStyle().Foreground(Text)
+
+	var lines []string
+	switch entry.Kind {
+	case data.EntryUser:
+		lines = append(lines, timestamp+lipgloss.NewStyle().Foreground(Primary).Bold(true).Render("❯ You"))
+		lines = append(lines, t.body(entry.Text, width, bodyStyle)...)
+
+	case data.EntryAssistant:
+		lines = append(lines, timestamp+lipgloss.NewStyle().Foreground(Success).Bold(true).Render("● Claude"))
+		lines = append(lines, t.body(entry.Text, width, bodyStyle)...)
+
+	case data.EntryThinking:
+		header := timestamp + MutedStyle.Render("✻ Thinking")
+		if !expanded {
+			count := len(wrapText(entry.Text, width))
+			lines = append(lines, header+MutedStyle.Render(fmt.Sprintf(" · %d lines ▸", count)))
+			break
+		}
+		lines = append(lines, header+MutedStyle.Render(" ▾"))
+		lines = append(lines, t.body(entry.Text, width, MutedStyle.Italic(true))...)
+
+	case data.EntryTool:
+		name := lipgloss.NewStyle().Foreground(Secondary).Bold(true).Render("⚙ " + entry.ToolName)
+		if !expanded {
+			input := truncate(entry.ToolInput, max(width-lipgloss.Width(name)-10, 10))
+			lines = append(lines, timestamp+name+" "+t.highlight(input, MutedStyle))
+		} else {
+			lines = append(lines, timestamp+name)
+			lines = append(lines, t.body(entry.ToolInput, width, MutedStyle)...)
+		}
+
+		resultStyle := MutedStyle
+		label := "result"
+		if entry.IsError {
+			resultStyle = ErrorStyle
+			label = "error"
+		}
+		if !entry.HasResult {
+			lines = append(lines, "  "+MutedStyle.Render("└ no result"))
+			break
+		}
+		count := len(wrapText(entry.Result, width-2))
+		if !expanded {
+			lines = append(lines, "  "+resultStyle.Render(fmt.Sprintf("└ %s · %d lines ▸", label, count)))
+			break
+		}
+		lines = append(lines, "  "+resultStyle.Render(fmt.Sprintf("└ %s ▾", label)))
+		for _, line := range wrapText(entry.Result, width-4) {
+			lines = append(lines, "    "+t.highlight(line, resultStyle))
+		}
+	}
+	return lines
+}
+
+// body wraps text and highlights search matches.
+func (t TranscriptModal) body(text string, width int, style lipgloss.Style) []string {
+	var lines []string
+	for _, line := range wrapText(text, width-2) {
+		lines = append(lines, "  "+t.highlight(line, style))
+	}
+	return lines
+}
+
+// highlight renders a line, marking occurrences of the search query.
+func (t TranscriptModal) highlight(line string, style lipgloss.Style) string {
+	if t.searchQuery == "" || t.searchMode {
+		return style.Render(line)
+	}
+	return highlightMatches(line, t.searchQuery, style)
+}
+
+// View renders the transcript modal.
+func (t TranscriptModal) View() string {
+	if !t.visible || t.session == nil {
+		return ""
+	}
+
+	modalWidth := t.width - 2
+	var lines []string
+
+	// Title
+	title := PanelTitleStyle.Render("Transcript")
+	summary := MutedStyle.Render(" " + truncate(t.session.ProjectName+" · "+t.session.Summary, max(modalWidth-16, 10)))
+	lines = append(lines, title+summary)
+	lines = append(lines, MutedStyle.Render(strings.Repeat("-", max(modalWidth-2, 0))))
+
+	visible := t.visibleLines()
+	switch {
+	case t.err != nil:
+		lines = append(lines, ErrorStyle.Render("Cannot read transcript: "+t.err.Error()))
+	case len(t.entries) == 0:
+		lines = append(lines, MutedStyle.Render("Transcript is empty"))
+	default:
+		end := min(t.offset+visible, len(t.lines))
+		lines = append(lines, t.lines[t.offset:end]...)
+	}
+	for len(lines) < visible+2 {
+		lines = append(lines, "")
+	}
+
+	lines = append(lines, t.statusLine())
+
+	content := strings.Join(lines, "\n")
+
+	style := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(Primary).
+		Padding(0, 1).
+		Width(modalWidth).
+		Height(t.height - 2)
+
+	return style.Render(content)
+}
+
+// statusLine renders the search prompt or position and key hints.
+func (t TranscriptModal) statusLine() string {
+	if t.searchMode {
+		return lipgloss.NewStyle().Foreground(Primary).Render("/" + t.searchQuery + "█")
+	}
+
+	var parts []string
+	if t.searchQuery != "" {
+		if len(t.matches) == 0 {
+			parts = append(parts, WarningStyle.Render("no matches: "+t.searchQuery))
+		} else {
+			parts = append(parts, WarningStyle.Render(fmt.Sprintf("match %d/%d", t.matchIdx+1, len(t.matches))))
+		}
+	}
+
+	position := 100
+	if maxOffset := len(t.lines) - t.visibleLines(); maxOffset > 0 {
+		position = t.offset * 100 / maxOffset
+	}
+	parts = append(parts, MutedStyle.Render(fmt.Sprintf("%d entries · %d%%", len(t.entries), position)))
+
+	hints := []Keybinding{
+		{"j/k", "scroll"},
+		{"u/i", "page"},
+		{"tab", "select"},
+		{"space", "fold"},
+		{"e/E", "all"},
+		{"/", "search"},
+		{"n/N", "match"},
+		{"esc", "close"},
+	}
+	// Drop hints that would wrap the status line
+	width := t.width - 6
+	for _, h := range hints {
+		hint := HelpKeyStyle.Render(h.Key) + " " + HelpDescStyle.Render(h.Desc)
+		if lipgloss.Width(strings.Join(append(parts, hint), "  ")) > width {
+			break
+		}
+		parts = append(parts, hint)
+	}
+	return strings.Join(parts, "  ")
+}
+
+// wrapText splits text into lines no wider than width,
+// breaking at spaces where possible.
+func wrapText(text string, width int) []string {
+	if width < 1 {
+		width = 1
+	}
+	var lines []string
+	for _, para := range strings.Split(strings.ReplaceAll(text, "\t", "    "), "\n") {
+		runes := []rune(strings.TrimRight(para, " \r"))
+		if len(runes) == 0 {
+			lines = append(lines, "")
+			continue
+		}
+		for len(runes) > width {
+			cut := width
+			for i := width; i > width/2; i-- {
+				if runes[i] == ' ' {
+					cut = i
+					break
+				}
+			}
+			lines = append(lines, string(runes[:cut]))
+			runes = runes[cut:]
+			if len(runes) > 0 && runes[0] == ' ' {
+				runes = runes[1:]
+			}
+		}
+		lines = append(lines, string(runes))
+	}
+	return lines
+}
+
+// highlightMatches renders text with case-insensitive occurrences of
+// query emphasized and the rest in the base style.
+func highlightMatches(text, query string, base lipgloss.Style) string {
+	if query == "" {
+		return base.Render(text)
+	}
+	matchStyle := lipgloss.NewStyle().Background(Warning).Foreground(SurfaceDark)
+	lower := strings.ToLower(text)
+	q := strings.ToLower(query)
+	if len(lower) != len(text) {
+		// Case folding changed byte offsets; skip highlighting
+		return base.Render(text)
+	}
+
+	var sb strings.Builder
+	pos := 0
+	for {
+		idx := strings.Index(lower[pos:], q)
+		if idx < 0 {
+			break
+		}
+		start := pos + idx
+		end := start + len(q)
+		if start > pos {
+			sb.WriteString(base.Render(text[pos:start]))
+		}
+		sb.WriteString(matchStyle.Render(text[start:end]))
+		pos = end
+	}
+	if pos < len(text) {
+		sb.WriteString(base.Render(text[pos:]))
+	}
+	return sb.String()
+}
@@ -0,0 +1,215 @@
+package ui
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/moshe-exe/lazyvibe/internal/data"
+	"github.com/moshe-exe/lazyvibe/internal/util"
+)
+
+// yankItem is a text the yank menu can copy.
+type yankItem struct {
+	key   string // Shortcut within the menu
+	label string
+	text  string
+}
+
+// sessionYankItems returns the texts that can be copied for a session.
+func sessionYankItems(s *data.SessionEntry, resumeTemplate string) []yankItem {
+	items := []yankItem{
+		{"i", "Session ID", s.SessionID},
+		{"p", "Project path", s.ProjectPath},
+		{"r", "Resume command", resumeCommandLine(resumeTemplate, s)},
+		{"s", "Summary", s.Summary},
+		{"l", "Markdown link", markdownLink(s.Summary, s.TranscriptPath)},
+		{"m", "Markdown summary", sessionMarkdown(s)},
+	}
+	return withoutEmpty(items)
+}
+
+// projectYankItems returns the texts that can be copied for a project.
+func projectYankItems(p *data.ProjectSummary) []yankItem {
+	items := []yankItem{
+		{"n", "Project name", p.ProjectName},
+		{"p", "Project path", p.ProjectPath},
+		{"l", "Markdown link", markdownLink(p.ProjectName, p.ProjectPath)},
+		{"m", "Markdown summary", projectMarkdown(p)},
+	}
+	return withoutEmpty(items)
+}
+
+// withoutEmpty drops items with nothing to copy.
+func withoutEmpty(items []yankItem) []yankItem {
+	var kept []yankItem
+	for _, item := range items {
+		if item.text != "" {
+			kept = append(kept, item)
+		}
+	}
+	return kept
+}
+
+// markdownLink returns a Markdown link to a local file, or "" if the path
+// is unknown.
+func markdownLink(title, path string) string {
+	if path == "" {
+		return ""
+	}
+	if title == "" {
+		title = path
+	}
+	title = strings.NewReplacer("[", `\[`, "]", `\]`).Replace(title)
+	return fmt.Sprintf("[%s](%s)", title, (&url.URL{Scheme: "file", Path: path}).String())
+}
+
+// sessionMarkdown returns a Markdown block describing a session.
+func sessionMarkdown(s *data.SessionEntry) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "### %s\n\n", s.Summary)
+	fmt.Fprintf(&b, "- Project: %s (`%s`)\n", s.ProjectName, s.ProjectPath)
+	fmt.Fprintf(&b, "- Session: `%s`\n", s.SessionID)
+	if s.GitBranch != nil && *s.GitBranch != "" {
+		fmt.Fprintf(&b, "- Branch: `%s`\n", *s.GitBranch)
+	}
+	fmt.Fprintf(&b, "- When: %s – %s (%s)\n",
+		util.FormatTime(s.Created, "2006-01-02 15:04"),
+		util.FormatTime(s.Modified, "15:04"),
+		s.FormatDuration())
+	fmt.Fprintf(&b, "- Messages: %d · Tokens: %s · Cost: %s\n",
+		s.MessageCount, formatTokens(s.Tokens.Total()), formatCost(s.Cost))
+	return b.String()
+}
+
+// projectMarkdown returns a Markdown block describing a project.
+func projectMarkdown(p *data.ProjectSummary) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "### %s\n\n", p.ProjectName)
+	fmt.Fprintf(&b, "- Path: `%s`\n", p.ProjectPath)
+	fmt.Fprintf(&b, "- Sessions: %d · Messages: %s · Cost: %s\n",
+		p.SessionCount, formatNumber(p.TotalMessages), formatCost(p.TotalCost))
+	fmt.Fprintf(&b, "- Last active: %s\n", util.FormatTime(p.LastActivity, "2006-01-02 15:04"))
+	if len(p.Branches) > 0 {
+		fmt.Fprintf(&b, "- Branches: %s\n", strings.Join(p.Branches, ", "))
+	}
+	if len(p.Models) > 0 {
+		fmt.Fprintf(&b, "- Models: %s\n", strings.Join(p.Models, ", "))
+	}
+	return b.String()
+}
+
+// YankMenu represents the menu of texts to copy for a session or project.
+type YankMenu struct {
+	visible bool
+	title   string
+	items   []yankItem
+	cursor  int
+	width   int
+	height  int
+}
+
+// NewYankMenu creates a new yank menu.
+func NewYankMenu() YankMenu {
+	return YankMenu{}
+}
+
+// SetSize sets the available dimensions.
+func (y *YankMenu) SetSize(width, height int) {
+	y.width = width
+	y.height = height
+}
+
+// Show displays the menu with the given items.
+func (y *YankMenu) Show(title string, items []yankItem) {
+	y.title = title
+	y.items = items
+	y.cursor = 0
+	y.visible = len(items) > 0
+}
+
+// Hide hides the menu.
+func (y *YankMenu) Hide() {
+	y.visible = false
+}
+
+// IsVisible returns whether the menu is visible.
+func (y *YankMenu) IsVisible() bool {
+	return y.visible
+}
+
+// CursorUp moves the selection up.
+func (y *YankMenu) CursorUp() {
+	if y.cursor > 0 {
+		y.cursor--
+	}
+}
+
+// CursorDown moves the selection down.
+func (y *YankMenu) CursorDown() {
+	if y.cursor < len(y.items)-1 {
+		y.cursor++
+	}
+}
+
+// Selected returns the selected item.
+func (y YankMenu) Selected() (yankItem, bool) {
+	if y.cursor >= len(y.items) {
+		return yankItem{}, false
+	}
+	return y.items[y.cursor], true
+}
+
+// ItemForKey returns the item with the given shortcut.
+func (y YankMenu) ItemForKey(key string) (yankItem, bool) {
+	for _, item := range y.items {
+		if item.key == key {
+			return item, true
+		}
+	}
+	return yankItem{}, false
+}
+
+// View renders the yank menu.
+func (y YankMenu) View() string {
+	if !y.visible {
+		return ""
+	}
+
+	modalWidth := min(max(y.width*60/100, 50), 80)
+	previewWidth := modalWidth - 30
+
+	lines := []string{
+		PanelTitleStyle.Render("Copy") + MutedStyle.Render(" "+truncate(y.title, modalWidth-12)),
+		MutedStyle.Render(strings.Repeat("-", modalWidth-4)),
+	}
+	for i, item := range y.items {
+		preview := strings.Join(strings.Fields(item.text), " ")
+		row := fmt.Sprintf("%s %-18s", item.key, item.label)
+		if i == y.cursor {
+			lines = append(lines, HighlightStyle.Render("▶ "+row)+" "+MutedStyle.Render(truncate(preview, previewWidth)))
+		} else {
+			lines = append(lines, "  "+HelpKeyStyle.Render(item.key)+fmt.Sprintf(" %-18s", item.label)+" "+MutedStyle.Render(truncate(preview, previewWidth)))
+		}
+	}
+	lines = append(lines, "", MutedStyle.Render("key or enter copy  esc close"))
+
+	modal := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(Primary).
+		Padding(1, 2).
+		Width(modalWidth).
+		Render(strings.Join(lines, "\n"))
+
+	// Center the modal
+	paddingLeft := max((y.width-modalWidth)/2, 0)
+	paddingTop := max((y.height-lipgloss.Height(modal))/2, 0)
+
+	leftPadding := strings.Repeat(" ", paddingLeft)
+	modalLines := strings.Split(modal, "\n")
+	for i, line := range modalLines {
+		modalLines[i] = leftPadding + line
+	}
+	return strings.Repeat("\n", paddingTop) + strings.Join(modalLines, "\n")
+}
@@ -3,8 +3,15 @@ package util
 
 import "time"
 
+// Unknown is displayed in place of an unknown (zero) time.
+const Unknown = "—"
+
 // FormatRelativeTime formats a time as a relative time string.
+// The zero time formats as Unknown.
 func FormatRelativeTime(t time.Time) string {
+	if t.IsZero() {
+		return Unknown
+	}
 	now := time.Now()
 	if t.Location() != nil {
 		now = now.In(t.Location())
@@ -32,7 +39,11 @@ func FormatRelativeTime(t time.Time) string {
 }
 
 // FormatRelativeTimeShort formats a time as a short relative time string.
+// The zero time formats as Unknown.
 func FormatRelativeTimeShort(t time.Time) string {
+	if t.IsZero() {
+		return Unknown
+	}
 	now := time.Now()
 	if t.Location() != nil {
 		now = now.In(t.Location())
@@ -59,6 +70,14 @@ func FormatRelativeTimeShort(t time.Time) string {
 	}
 }
 
+// FormatTime formats a time with layout, or returns Unknown for the zero time.
+func FormatTime(t time.Time, layout string) string {
+	if t.IsZero() {
+		return Unknown
+	}
+	return t.Format(layout)
+}
+
 func formatDuration(value int, unit string) string {
 	return formatDurationShort(value, unit) + " ago"
 }